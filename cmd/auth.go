@@ -55,7 +55,7 @@ var authCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		fmt.Printf("Authenticated as %s\n", username)
+		fmt.Printf("Authenticated as %s on %s\n", username, cli.Site())
 		fmt.Printf("Saved auth config to %s\n", path)
 		return nil
 	},
@@ -65,9 +65,9 @@ var authGuideCmd = &cobra.Command{
 	Use:   "guide",
 	Short: "Show quickest way to obtain LeetCode cookies",
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("1) Open https://leetcode.com in your browser and log in.")
+		fmt.Println("1) Open https://leetcode.com (or https://leetcode.cn) in your browser and log in.")
 		fmt.Println("2) Open DevTools -> Network and refresh the page.")
-		fmt.Println("3) Click any request to leetcode.com and copy the full 'cookie' request header.")
+		fmt.Println("3) Click any request to the site and copy the full 'cookie' request header.")
 		fmt.Println("4) Run: leet auth --cookie '<PASTED_COOKIE_HEADER>' --project")
 		fmt.Println("   (or omit --project to save in XDG config).")
 	},
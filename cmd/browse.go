@@ -1,19 +1,27 @@
 package cmd
 
 import (
+	"bufio"
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"os/signal"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
+
+	"leetcli/internal/leetcode"
 )
 
+var browseAddFailing bool
+
 var browseCmd = &cobra.Command{
 	Use:   "browse",
 	Short: "Interactive problem browser",
@@ -44,6 +52,11 @@ type browseModel struct {
 	difficulty string
 	status     string
 	msg        string
+	// remote switches the list from the local cache to LeetCode's problemset
+	// search; searchSeq discards results of superseded searches.
+	remote    bool
+	searchSeq int
+	total     int
 }
 
 type browseItem struct {
@@ -51,6 +64,7 @@ type browseItem struct {
 	title      string
 	difficulty string
 	status     string
+	acRate     float64
 }
 
 type submitDoneMsg struct {
@@ -58,6 +72,18 @@ type submitDoneMsg struct {
 	err  error
 }
 
+type searchDoneMsg struct {
+	seq   int
+	items []browseItem
+	total int
+	err   error
+}
+
+type preparedMsg struct {
+	slug string
+	err  error
+}
+
 func newBrowseModel(ctx context.Context, a *app) (browseModel, error) {
 	q := textinput.New()
 	q.Placeholder = "search slug/title"
@@ -90,6 +116,57 @@ func (m *browseModel) reload() error {
 	return nil
 }
 
+// searchDebounce is how long typing must pause before a remote search is
+// sent.
+const searchDebounce = 300 * time.Millisecond
+
+type searchDueMsg struct{ seq int }
+
+// refresh reloads the list from the cache, or schedules a remote search
+// once typing pauses.
+func (m *browseModel) refresh() tea.Cmd {
+	if !m.remote {
+		_ = m.reload()
+		return nil
+	}
+	m.searchSeq++
+	seq := m.searchSeq
+	return tea.Tick(searchDebounce, func(time.Time) tea.Msg { return searchDueMsg{seq: seq} })
+}
+
+func (m browseModel) searchCmd(seq int) tea.Cmd {
+	f := leetcode.SearchFilter{Keyword: strings.TrimSpace(m.query.Value()), Difficulty: m.difficulty, Status: m.status}
+	return func() tea.Msg {
+		rows, total, err := m.a.client().SearchQuestions(m.ctx, f, 0, 50)
+		if err != nil {
+			return searchDoneMsg{seq: seq, err: err}
+		}
+		items := make([]browseItem, 0, len(rows))
+		for _, r := range rows {
+			if r.PaidOnly {
+				continue
+			}
+			items = append(items, browseItem{slug: r.Slug, title: r.Title, difficulty: r.Difficulty, status: remoteStatus(r.Status), acRate: r.AcRate})
+		}
+		return searchDoneMsg{seq: seq, items: items, total: total}
+	}
+}
+
+// prepareCmd fetches an uncached remote result so it can be opened.
+func (m browseModel) prepareCmd(slug string) tea.Cmd {
+	return func() tea.Msg {
+		if p, err := m.a.store.GetProblem(m.ctx, slug); err == nil && p.StatementHTML != "" {
+			return preparedMsg{slug: slug}
+		}
+		q, err := m.a.client().Question(m.ctx, slug)
+		if err != nil {
+			return preparedMsg{slug: slug, err: err}
+		}
+		_, err = prepareProblem(m.ctx, m.a, q, m.a.problemLang(m.ctx, slug))
+		return preparedMsg{slug: slug, err: err}
+	}
+}
+
 func (m browseModel) Init() tea.Cmd { return nil }
 
 func (m browseModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -108,20 +185,31 @@ func (m browseModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		case "ctrl+u":
 			m.query.SetValue("")
-			_ = m.reload()
+			return m, m.refresh()
+		case "ctrl+r":
+			m.remote = !m.remote
+			m.cursor = 0
+			m.msg = ""
+			if m.remote {
+				m.items = nil
+				m.msg = "searching leetcode.com..."
+			}
+			return m, m.refresh()
 		case "tab":
 			m.difficulty = nextDifficulty(m.difficulty)
-			_ = m.reload()
+			return m, m.refresh()
 		case "shift+tab":
 			m.status = nextStatus(m.status)
-			_ = m.reload()
+			return m, m.refresh()
 		case "s":
 			if it, ok := m.selected(); ok {
 				next := cycleProblemStatus(it.status)
 				_ = m.a.store.SetProblemStatus(m.ctx, it.slug, next)
-				_ = syncMeta(m.ctx, m.a, it.slug)
+				_ = syncMeta(m.ctx, m.a, it.slug, m.a.problemLang(m.ctx, it.slug))
 				m.msg = fmt.Sprintf("status: %s -> %s", it.slug, next)
-				_ = m.reload()
+				if !m.remote {
+					_ = m.reload()
+				}
 			}
 		case "t":
 			if it, ok := m.selected(); ok {
@@ -131,7 +219,7 @@ func (m browseModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "T":
 			if it, ok := m.selected(); ok {
 				d, _ := m.a.store.StopTimer(m.ctx, it.slug)
-				_ = syncMeta(m.ctx, m.a, it.slug)
+				_ = syncMeta(m.ctx, m.a, it.slug, m.a.problemLang(m.ctx, it.slug))
 				m.msg = fmt.Sprintf("timer stopped: %s (+%ds)", it.slug, d)
 			}
 		case "n":
@@ -140,44 +228,77 @@ func (m browseModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, tea.ExecProcess(editorCmd(path), nil)
 			}
 		case "enter", "o":
-			if it, ok := m.selected(); ok {
-				path := solutionPath(m.a.cfg.Workspace.ProblemsDir, it.slug)
+			if it, ok := m.selected(); ok && m.remote {
+				m.msg = "fetching " + it.slug + "..."
+				return m, m.prepareCmd(it.slug)
+			} else if ok {
+				path := m.a.workingSolution(m.ctx, it.slug, m.a.problemLang(m.ctx, it.slug))
 				_ = m.a.store.SetCurrentProblem(m.ctx, it.slug)
 				return m, tea.ExecProcess(editorCmd(path), nil)
 			}
 		case "u":
 			if it, ok := m.selected(); ok {
-				m.msg = "submitting " + it.slug + "..."
 				return m, m.submitCmd(it.slug)
 			}
 		default:
 			var cmd tea.Cmd
 			m.query, cmd = m.query.Update(t)
 			if keyLikelyChangesQuery(t) {
-				_ = m.reload()
+				return m, tea.Batch(cmd, m.refresh())
 			}
 			return m, cmd
 		}
+	case searchDueMsg:
+		if t.seq != m.searchSeq || !m.remote {
+			return m, nil
+		}
+		return m, m.searchCmd(t.seq)
+	case searchDoneMsg:
+		if t.seq != m.searchSeq || !m.remote {
+			return m, nil
+		}
+		if t.err != nil {
+			m.msg = "search error: " + t.err.Error()
+			return m, nil
+		}
+		m.items, m.total, m.msg = t.items, t.total, ""
+		if m.cursor >= len(m.items) {
+			m.cursor = 0
+		}
+	case preparedMsg:
+		if t.err != nil {
+			m.msg = "fetch error: " + t.err.Error()
+			return m, nil
+		}
+		m.msg = ""
+		_ = m.a.store.SetCurrentProblem(m.ctx, t.slug)
+		return m, tea.ExecProcess(editorCmd(m.a.workingSolution(m.ctx, t.slug, m.a.problemLang(m.ctx, t.slug))), nil)
 	case submitDoneMsg:
 		if t.err != nil {
 			m.msg = "submit error: " + t.err.Error()
 		} else {
 			m.msg = t.text
 		}
-		_ = m.reload()
+		return m, m.refresh()
 	}
 	return m, nil
 }
 
 func (m browseModel) View() string {
 	header := lipgloss.NewStyle().Bold(true).Render("LeetCLI Browse")
-	sub := fmt.Sprintf("search=%q  difficulty=%s(tab)  status=%s(shift+tab)", m.query.Value(), blankAsAll(m.difficulty), blankAsAll(m.status))
+	source := "cache"
+	if m.remote {
+		source = fmt.Sprintf("leetcode (%d matches)", m.total)
+	}
+	sub := fmt.Sprintf("search=%q  difficulty=%s(tab)  status=%s(shift+tab)  source=%s(ctrl+r)", m.query.Value(), blankAsAll(m.difficulty), blankAsAll(m.status), source)
 	legend := "enter/o open  s mark status  t/T timer start/stop  n note  u submit  q quit"
 	var b strings.Builder
 	b.WriteString(header + "\n")
 	b.WriteString(sub + "\n")
 	b.WriteString(legend + "\n\n")
-	if len(m.items) == 0 {
+	if len(m.items) == 0 && m.remote {
+		b.WriteString("No matches.\n")
+	} else if len(m.items) == 0 {
 		b.WriteString("No cached problems. Run `leet solve --random` first.\n")
 	} else {
 		for i, it := range m.items {
@@ -185,6 +306,10 @@ func (m browseModel) View() string {
 			if i == m.cursor {
 				cursor = ">"
 			}
+			if m.remote {
+				b.WriteString(fmt.Sprintf("%s %-6s %-7s %-11s %5.1f%%  %s\n", cursor, it.slug, it.difficulty, it.status, it.acRate, it.title))
+				continue
+			}
 			b.WriteString(fmt.Sprintf("%s %-6s %-7s %-11s %s\n", cursor, it.slug, it.difficulty, it.status, it.title))
 		}
 	}
@@ -201,31 +326,44 @@ func (m browseModel) selected() (browseItem, bool) {
 	return m.items[m.cursor], true
 }
 
+// submitCmd hands the terminal to submitSolution, so a browse submission
+// prints and records its verdict exactly like leet submit.
 func (m browseModel) submitCmd(slug string) tea.Cmd {
-	return func() tea.Msg {
-		p, err := m.a.store.GetProblem(m.ctx, slug)
-		if err != nil {
-			return submitDoneMsg{err: err}
-		}
-		if p.QuestionID == "" {
-			q, qErr := m.a.client().Question(m.ctx, slug)
-			if qErr != nil {
-				return submitDoneMsg{err: qErr}
-			}
-			p.QuestionID = q.QuestionID
-		}
-		code, err := os.ReadFile(solutionPath(m.a.cfg.Workspace.ProblemsDir, slug))
+	run := &submitExec{ctx: m.ctx, a: m.a, slug: slug}
+	return tea.Exec(run, func(err error) tea.Msg {
 		if err != nil {
 			return submitDoneMsg{err: err}
 		}
-		res, err := m.a.client().Submit(m.ctx, slug, p.QuestionID, string(code))
-		if err != nil {
-			return submitDoneMsg{err: err}
-		}
-		_ = m.a.store.SaveSubmissionResult(m.ctx, slug, res.Status, res.Runtime, res.Memory)
-		_ = syncMeta(m.ctx, m.a, slug)
-		return submitDoneMsg{text: fmt.Sprintf("submission %d: %s", res.SubmissionID, res.Status)}
+		return submitDoneMsg{text: "submitted " + slug}
+	})
+}
+
+// submitExec runs a submission while browse is suspended.
+type submitExec struct {
+	ctx  context.Context
+	a    *app
+	slug string
+	in   io.Reader
+}
+
+func (e *submitExec) SetStdin(r io.Reader) { e.in = r }
+func (e *submitExec) SetStdout(io.Writer)  {}
+func (e *submitExec) SetStderr(io.Writer)  {}
+
+func (e *submitExec) Run() error {
+	ctx, stop := signal.NotifyContext(e.ctx, os.Interrupt)
+	defer stop()
+	err := submitSolution(ctx, e.a, e.slug, e.a.problemLang(ctx, e.slug), browseAddFailing)
+	if err != nil {
+		fmt.Println("Error:", err)
+	}
+	fmt.Print("\nPress Enter to return to browse ")
+	in := e.in
+	if in == nil {
+		in = os.Stdin
 	}
+	_, _ = bufio.NewReader(in).ReadString('\n')
+	return err
 }
 
 func editorCmd(path string) *exec.Cmd {
@@ -273,6 +411,17 @@ func cycleProblemStatus(v string) string {
 	return "todo"
 }
 
+func remoteStatus(s string) string {
+	switch s {
+	case "ac":
+		return "solved"
+	case "notac":
+		return "in_progress"
+	default:
+		return "todo"
+	}
+}
+
 func keyLikelyChangesQuery(k tea.KeyMsg) bool {
 	s := k.String()
 	if len(s) == 1 {
@@ -280,3 +429,7 @@ func keyLikelyChangesQuery(k tea.KeyMsg) bool {
 	}
 	return s == "backspace" || s == "delete" || s == "space"
 }
+
+func init() {
+	browseCmd.Flags().BoolVar(&browseAddFailing, "add-failing", false, "append the failing testcase of a browse submission to tests.json")
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"leetcli/internal/leetcode"
+	"leetcli/internal/store"
+)
+
+var catalogCmd = &cobra.Command{
+	Use:   "catalog",
+	Short: "Show the offline problem catalog",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		a, err := loadApp(ctx)
+		if err != nil {
+			return err
+		}
+		defer a.close()
+
+		at, n, err := a.store.CatalogInfo(ctx)
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			fmt.Println("Catalog not downloaded yet. Run `leet catalog refresh`.")
+			return nil
+		}
+		fmt.Printf("Catalog: %d problems, refreshed %s\n", n, at.Format("2006-01-02 15:04"))
+		if catalogStale(a, at) {
+			fmt.Printf("Older than %dh; it will be refreshed on the next random pick\n", a.cfg.Catalog.TTLHours)
+		}
+		return nil
+	},
+}
+
+var catalogRefreshCmd = &cobra.Command{
+	Use:   "refresh",
+	Short: "Download the full problem catalog",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		a, err := loadApp(ctx)
+		if err != nil {
+			return err
+		}
+		defer a.close()
+
+		n, err := refreshCatalog(ctx, a)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Catalog refreshed: %d problems\n", n)
+		return nil
+	},
+}
+
+const catalogPageSize = 500
+
+func refreshCatalog(ctx context.Context, a *app) (int, error) {
+	cli := a.client()
+	entries := make([]store.CatalogEntry, 0)
+	for {
+		page, total, err := cli.SearchQuestions(ctx, leetcode.SearchFilter{}, len(entries), catalogPageSize)
+		if err != nil {
+			return 0, err
+		}
+		for _, s := range page {
+			entries = append(entries, store.CatalogEntry{
+				FrontendID: s.FrontendID,
+				Slug:       s.Slug,
+				Title:      s.Title,
+				Difficulty: s.Difficulty,
+				PaidOnly:   s.PaidOnly,
+				Tags:       s.Topics,
+				AcRate:     s.AcRate,
+			})
+		}
+		if len(page) == 0 || len(entries) >= total {
+			break
+		}
+	}
+	if len(entries) == 0 {
+		return 0, fmt.Errorf("catalog download returned no problems")
+	}
+	if err := a.store.ReplaceCatalog(ctx, entries); err != nil {
+		return 0, err
+	}
+	return len(entries), nil
+}
+
+// ensureCatalog refreshes a missing or stale catalog. When the refresh fails
+// but an older catalog exists it reports offline=true and keeps using it.
+func ensureCatalog(ctx context.Context, a *app) (bool, error) {
+	at, n, err := a.store.CatalogInfo(ctx)
+	if err != nil {
+		return false, err
+	}
+	if n > 0 && !catalogStale(a, at) {
+		return false, nil
+	}
+	if _, err := refreshCatalog(ctx, a); err != nil {
+		if n == 0 {
+			return false, fmt.Errorf("download catalog: %w", err)
+		}
+		fmt.Printf("Catalog refresh failed (%v); using catalog from %s\n", err, at.Format("2006-01-02"))
+		return true, nil
+	}
+	return false, nil
+}
+
+func catalogStale(a *app, at time.Time) bool {
+	ttl := time.Duration(a.cfg.Catalog.TTLHours) * time.Hour
+	return ttl > 0 && time.Since(at) > ttl
+}
+
+func init() {
+	catalogCmd.AddCommand(catalogRefreshCmd)
+}
@@ -0,0 +1,259 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"leetcli/internal/contest"
+	"leetcli/internal/store"
+	"leetcli/internal/workspace"
+)
+
+var contestLang string
+
+var contestCmd = &cobra.Command{
+	Use:   "contest",
+	Short: "Replay past weekly and biweekly contests as virtual contests",
+}
+
+var contestStartCmd = &cobra.Command{
+	Use:   "start <contest-slug>",
+	Short: "Set up a past contest's problems under one shared contest clock",
+	Example: `  leet contest start weekly-contest-400
+  leet contest start biweekly-contest-130`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		a, err := loadApp(ctx)
+		if err != nil {
+			return err
+		}
+		defer a.close()
+
+		if run, ok := a.runningContest(ctx); ok {
+			return fmt.Errorf("%s is still running; see leet contest status or finish it with leet contest end", run.Title)
+		}
+		l, err := a.lang(contestLang)
+		if err != nil {
+			return err
+		}
+		cli := a.client()
+		c, err := cli.Contest(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		problems := make([]store.ContestProblem, 0, len(c.Questions))
+		for _, cq := range c.Questions {
+			q, err := cli.Question(ctx, cq.Slug)
+			if err != nil {
+				return err
+			}
+			if _, err := prepareProblem(ctx, a, q, l); err != nil {
+				return err
+			}
+			problems = append(problems, store.ContestProblem{Slug: cq.Slug, Title: q.Title, Credit: cq.Credit})
+		}
+		duration := c.Duration
+		if duration <= 0 {
+			duration = contest.DefaultDuration
+		}
+		run, err := a.store.StartContestRun(ctx, c.Slug, c.Title, int(duration.Minutes()), problems)
+		if err != nil {
+			return err
+		}
+		_ = a.store.SetCurrentProblem(ctx, problems[0].Slug)
+		// The shared clock is a timer under the contest slug, which timer
+		// watch falls back to for the contest's problems.
+		if err := a.store.StartTimer(ctx, run.ContestSlug, run.DurationMin, false); err != nil {
+			return err
+		}
+
+		fmt.Printf("%s started: %d minutes, ends at %s\n\n", c.Title, run.DurationMin, run.Deadline().Format("15:04"))
+		for i, p := range problems {
+			fmt.Printf("Q%d  %-40s %d pts  %s\n", i+1, truncate(p.Title, 40), p.Credit, mutedStyle.Render(workspace.ProblemDir(a.cfg.Workspace.ProblemsDir, p.Slug)))
+		}
+		fmt.Printf("\nEach wrong answer on a problem you solve adds %d minutes. Check the standing with leet contest status.\n", int(contest.WrongPenalty.Minutes()))
+		fmt.Println("Watch the contest clock with leet timer watch.")
+		return nil
+	},
+}
+
+var contestStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the clock and standing of the running contest",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		a, err := loadApp(ctx)
+		if err != nil {
+			return err
+		}
+		defer a.close()
+
+		run, ok := a.runningContest(ctx)
+		if !ok {
+			fmt.Println("No contest running. Start one with leet contest start weekly-contest-400")
+			return nil
+		}
+		left := time.Until(run.Deadline())
+		if left <= 0 {
+			fmt.Printf("Time is up for %s.\n\n", run.Title)
+			return endContest(ctx, a, run)
+		}
+		st, err := contestStanding(ctx, a, run)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s: %s left (ends at %s)\n\n", run.Title, left.Round(time.Second), run.Deadline().Format("15:04"))
+		printStanding(st)
+		return nil
+	},
+}
+
+var contestEndCmd = &cobra.Command{
+	Use:   "end",
+	Short: "Finish the running contest and show the final score",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		a, err := loadApp(ctx)
+		if err != nil {
+			return err
+		}
+		defer a.close()
+
+		run, ok := a.runningContest(ctx)
+		if !ok {
+			return fmt.Errorf("no contest running")
+		}
+		return endContest(ctx, a, run)
+	},
+}
+
+var contestListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List past contest runs with their scores",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		a, err := loadApp(ctx)
+		if err != nil {
+			return err
+		}
+		defer a.close()
+
+		runs, err := a.store.ListContestRuns(ctx)
+		if err != nil {
+			return err
+		}
+		if len(runs) == 0 {
+			fmt.Println("No contest runs yet")
+			return nil
+		}
+		for _, r := range runs {
+			total := 0
+			for _, p := range r.Problems {
+				total += p.Credit
+			}
+			result := failStyle.Render("running")
+			if !r.FinishedAt.IsZero() {
+				result = fmt.Sprintf("%2d/%-2d pts  finish %s  (%d penalties)", r.Score, total, contest.Clock(time.Duration(r.FinishSec)*time.Second), r.Penalties)
+			}
+			fmt.Printf("#%-4d %s  %-28s %s\n", r.ID, r.StartedAt.Format("2006-01-02 15:04"), truncate(r.Title, 28), result)
+		}
+		return nil
+	},
+}
+
+// runningContest returns the unfinished contest run, if any.
+func (a *app) runningContest(ctx context.Context) (store.ContestRun, bool) {
+	run, err := a.store.ActiveContestRun(ctx)
+	if err != nil {
+		return store.ContestRun{}, false
+	}
+	return run, true
+}
+
+// contestStanding scores run from the submissions made since it started.
+func contestStanding(ctx context.Context, a *app, run store.ContestRun) (contest.Standing, error) {
+	window := time.Duration(run.DurationMin) * time.Minute
+	results := make([]contest.ProblemResult, 0, len(run.Problems))
+	for _, p := range run.Problems {
+		stored, err := a.store.SubmissionsSince(ctx, p.Slug, run.StartedAt)
+		if err != nil {
+			return contest.Standing{}, err
+		}
+		subs := make([]contest.Submission, 0, len(stored))
+		for _, sub := range stored {
+			subs = append(subs, contest.Submission{At: submittedAt(sub).Sub(run.StartedAt), Status: sub.Status})
+		}
+		results = append(results, contest.Judge(contest.ProblemResult{Slug: p.Slug, Title: p.Title, Credit: p.Credit}, subs, window))
+	}
+	return contest.Tally(results), nil
+}
+
+func printStanding(st contest.Standing) {
+	for i, p := range st.Problems {
+		state := mutedStyle.Render("-")
+		if p.Solved {
+			state = passStyle.Render(contest.Clock(p.SolvedAt))
+		}
+		wrong := ""
+		if p.Wrong > 0 {
+			wrong = failStyle.Render(fmt.Sprintf("(%d)", p.Wrong))
+		}
+		fmt.Printf("Q%d  %-40s %d pts  %s %s\n", i+1, truncate(p.Title, 40), p.Credit, state, wrong)
+	}
+	fmt.Printf("\nScore %d/%d  finish time %s", st.Score, st.MaxScore, contest.Clock(st.Finish))
+	if st.Penalties > 0 {
+		fmt.Printf(" (includes %d x %d min penalty)", st.Penalties, int(contest.WrongPenalty.Minutes()))
+	}
+	fmt.Println()
+}
+
+// endContest stores the final standing of run and prints it.
+func endContest(ctx context.Context, a *app, run store.ContestRun) error {
+	st, err := contestStanding(ctx, a, run)
+	if err != nil {
+		return err
+	}
+	if err := a.store.FinishContestRun(ctx, run.ID, st.Score, int(st.Finish.Seconds()), st.Penalties); err != nil {
+		return err
+	}
+	if _, err := a.store.StopTimer(ctx, run.ContestSlug); err != nil {
+		return err
+	}
+	fmt.Printf("%s finished\n\n", run.Title)
+	printStanding(st)
+	return nil
+}
+
+// printContestProgress follows a submission of a contest problem with the
+// updated standing.
+func printContestProgress(ctx context.Context, a *app, slug string) {
+	run, ok := a.runningContest(ctx)
+	if !ok || !run.Has(slug) {
+		return
+	}
+	if time.Now().After(run.Deadline()) {
+		fmt.Println(mutedStyle.Render("The contest clock has run out; this submission does not count. Run leet contest end."))
+		return
+	}
+	st, err := contestStanding(ctx, a, run)
+	if err != nil {
+		return
+	}
+	fmt.Println(mutedStyle.Render(fmt.Sprintf("%s: %d/%d pts, finish time %s, %s left",
+		run.Title, st.Score, st.MaxScore, contest.Clock(st.Finish), time.Until(run.Deadline()).Round(time.Second))))
+}
+
+func init() {
+	contestStartCmd.Flags().StringVar(&contestLang, "lang", "", "solution language (defaults to config language)")
+	contestCmd.AddCommand(contestStartCmd)
+	contestCmd.AddCommand(contestStatusCmd)
+	contestCmd.AddCommand(contestEndCmd)
+	contestCmd.AddCommand(contestListCmd)
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestContestClock(t *testing.T) {
+	srv := startFake(t)
+	// Keep the judge busy for a few polls so the time recorded after
+	// judging differs from when the submission was sent.
+	srv.PendingPolls = 2
+
+	if _, err := runLeet(t, "contest", "start", "weekly-contest-fake", "--lang", "python3"); err != nil {
+		t.Fatalf("contest start: %v", err)
+	}
+	ctx := context.Background()
+	a, err := loadApp(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	run, ok := a.runningContest(ctx)
+	if !ok {
+		a.close()
+		t.Fatal("no contest running after contest start")
+	}
+	tm, err := a.store.ActiveTimer(ctx, run.ContestSlug)
+	a.close()
+	if err != nil {
+		t.Fatalf("contest timer: %v", err)
+	}
+	if tm.TargetMinutes != run.DurationMin {
+		t.Errorf("contest timer target = %d minutes, want %d", tm.TargetMinutes, run.DurationMin)
+	}
+
+	slug := run.Problems[0].Slug
+	if _, err := runLeet(t, "submit", slug); err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+	out, err := runLeet(t, "contest", "end")
+	if err != nil {
+		t.Fatalf("contest end: %v", err)
+	}
+	if !strings.Contains(out, "finish time 0:00:00") && !strings.Contains(out, "finish time 0:00:01") {
+		t.Errorf("contest end output = %q, want the solve timed from when it was sent", out)
+	}
+
+	a, err = loadApp(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer a.close()
+	if _, err := a.store.ActiveTimer(ctx, run.ContestSlug); err == nil {
+		t.Error("contest timer still running after contest end")
+	}
+}
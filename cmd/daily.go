@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var dailyTimer int
+var dailyNoTimer bool
+var dailyLang string
+
+var dailyCmd = &cobra.Command{
+	Use:   "daily",
+	Short: "Prepare today's LeetCode daily challenge",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		a, err := loadApp(ctx)
+		if err != nil {
+			return err
+		}
+		defer a.close()
+
+		l, err := a.lang(dailyLang)
+		if err != nil {
+			return err
+		}
+		cli := a.client()
+		d, err := cli.DailyChallenge(ctx)
+		if err != nil {
+			return err
+		}
+		q, err := cli.Question(ctx, d.Slug)
+		if err != nil {
+			return err
+		}
+		if _, err := prepareProblem(ctx, a, q, l); err != nil {
+			return err
+		}
+		if err := a.store.RecordDaily(ctx, d.Date, d.Slug, d.Ends); err != nil {
+			return err
+		}
+		_ = a.store.SetCurrentProblem(ctx, d.Slug)
+		if !dailyNoTimer {
+			_ = a.store.StartTimer(ctx, d.Slug, dailyTimer, false)
+		}
+
+		streak, _ := a.store.DailyStreak(ctx)
+		fmt.Printf("Daily %s: %s. %s [%s]\n", d.Date, d.FrontendID, d.Title, d.Difficulty)
+		if today, err := a.store.TodayDaily(ctx); err == nil && today.CompletedAt != "" {
+			fmt.Println("Already completed today")
+		}
+		fmt.Printf("Streak: %d day(s)\n", streak)
+		fmt.Printf("Open: leet open %s\n", d.Slug)
+		if !dailyNoTimer {
+			fmt.Printf("Timer started: %d minutes\n", dailyTimer)
+		}
+		return nil
+	},
+}
+
+func init() {
+	dailyCmd.Flags().IntVar(&dailyTimer, "timer", 30, "default solve timer in minutes")
+	dailyCmd.Flags().BoolVar(&dailyNoTimer, "no-timer", false, "do not auto-start timer")
+	dailyCmd.Flags().StringVar(&dailyLang, "lang", "", "solution language (defaults to config language)")
+}
@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"leetcli/internal/leetcode/fake"
+)
+
+// startFake points the commands at a fresh fake server and runs them in an
+// empty workspace.
+func startFake(t *testing.T) *fake.Server {
+	t.Helper()
+	srv := fake.Start()
+	t.Cleanup(srv.Close)
+
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("LEETCODE_SITE", srv.URL)
+	t.Setenv("LEETCODE_SESSION", srv.Session)
+	t.Setenv("CSRFTOKEN", srv.CSRF)
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+	return srv
+}
+
+// runLeet runs the command line args and returns what it printed to stdout.
+func runLeet(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	resetFlags(rootCmd)
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	out := make(chan string)
+	go func() {
+		b, _ := io.ReadAll(r)
+		out <- string(b)
+	}()
+
+	rootCmd.SilenceUsage, rootCmd.SilenceErrors = true, true
+	rootCmd.SetArgs(args)
+	err = rootCmd.Execute()
+	os.Stdout = stdout
+	_ = w.Close()
+	return <-out, err
+}
+
+// resetFlags restores every flag to its default so one run's flags do not
+// leak into the next.
+func resetFlags(c *cobra.Command) {
+	reset := func(f *pflag.Flag) {
+		if s, ok := f.Value.(pflag.SliceValue); ok {
+			_ = s.Replace(nil)
+		} else {
+			_ = f.Value.Set(f.DefValue)
+		}
+		f.Changed = false
+	}
+	c.Flags().VisitAll(reset)
+	c.PersistentFlags().VisitAll(reset)
+	for _, sub := range c.Commands() {
+		resetFlags(sub)
+	}
+}
+
+func TestFakeAuth(t *testing.T) {
+	srv := startFake(t)
+
+	out, err := runLeet(t, "auth")
+	if err != nil {
+		t.Fatalf("auth: %v", err)
+	}
+	if !strings.Contains(out, "Authenticated as "+srv.Username) {
+		t.Errorf("auth output = %q, want the fake username", out)
+	}
+
+	if _, err := runLeet(t, "auth", "--session", "wrong"); err == nil {
+		t.Error("auth with a bad session succeeded")
+	}
+}
+
+func TestFakeSolveAndSubmit(t *testing.T) {
+	srv := startFake(t)
+
+	if _, err := runLeet(t, "solve", "--slug", "two-sum", "--no-timer", "--lang", "python3"); err != nil {
+		t.Fatalf("solve: %v", err)
+	}
+	for _, name := range []string{"README.md", "solution.py"} {
+		if _, err := os.Stat(filepath.Join("problems", "two-sum", name)); err != nil {
+			t.Errorf("solve did not write %s: %v", name, err)
+		}
+	}
+
+	out, err := runLeet(t, "submit", "two-sum")
+	if err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+	if !strings.Contains(out, "Accepted") {
+		t.Errorf("submit output = %q, want Accepted", out)
+	}
+	subs := srv.Submissions()
+	if len(subs) != 1 || subs[0].Slug != "two-sum" || subs[0].Lang != "python3" {
+		t.Fatalf("fake received %+v, want one python3 two-sum submission", subs)
+	}
+	if !strings.Contains(subs[0].Code, "class Solution") {
+		t.Errorf("submitted code = %q, want the solution file", subs[0].Code)
+	}
+}
+
+func TestFakeSubmitAddFailing(t *testing.T) {
+	srv := startFake(t)
+	srv.SetVerdict("two-sum", fake.WrongAnswer)
+
+	if _, err := runLeet(t, "solve", "--slug", "two-sum", "--no-timer", "--lang", "python3"); err != nil {
+		t.Fatalf("solve: %v", err)
+	}
+	out, err := runLeet(t, "submit", "two-sum", "--add-failing")
+	if err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+	if !strings.Contains(out, "Wrong Answer") {
+		t.Errorf("submit output = %q, want Wrong Answer", out)
+	}
+	b, err := os.ReadFile(filepath.Join("problems", "two-sum", "tests.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tests []json.RawMessage
+	if err := json.Unmarshal(b, &tests); err != nil {
+		t.Fatalf("decode tests.json: %v\n%s", err, b)
+	}
+	if len(tests) != 1 {
+		t.Errorf("tests.json holds %d cases, want the failing case:\n%s", len(tests), b)
+	}
+}
+
+func TestFakeRemoteTest(t *testing.T) {
+	tests := []struct {
+		verdict fake.Verdict
+		wantErr bool
+		want    string
+	}{
+		{fake.Accepted, false, "Tests passed for two-sum"},
+		{fake.WrongAnswer, true, "Wrong Answer (failed=1)"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.verdict), func(t *testing.T) {
+			srv := startFake(t)
+			srv.SetVerdict("two-sum", tt.verdict)
+
+			if _, err := runLeet(t, "solve", "--slug", "two-sum", "--no-timer", "--lang", "python3"); err != nil {
+				t.Fatalf("solve: %v", err)
+			}
+			out, err := runLeet(t, "test", "two-sum", "--remote")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("test --remote error = %v, want error %v\n%s", err, tt.wantErr, out)
+			}
+			if !strings.Contains(out, tt.want) {
+				t.Errorf("test --remote output = %q, want %q", out, tt.want)
+			}
+		})
+	}
+}
@@ -58,6 +58,14 @@ var fetchCmd = &cobra.Command{
 		fmt.Printf("%s topic coverage: %d\n", maize.Render("+"), st.TopicCoverage)
 		fmt.Printf("%s avg solve time: %.1f min\n", maize.Render("+"), st.AvgSolveSec/60.0)
 		fmt.Printf("%s momentum: %s\n", maize.Render("+"), maize.Render(impText))
+		daily := "not fetched today (leet daily)"
+		if st.DailySlug != "" {
+			daily = st.DailySlug + " pending"
+			if st.DailyDone {
+				daily = st.DailySlug + " done"
+			}
+		}
+		fmt.Printf("%s daily: %s, streak %d\n", maize.Render("+"), daily, st.DailyStreak)
 		fmt.Println("\nRecent activity:")
 		if len(st.RecentActivity) == 0 {
 			fmt.Println("  (none yet)")
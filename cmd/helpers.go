@@ -4,19 +4,27 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"path/filepath"
+	"os"
 	"strings"
+	"sync"
+	"time"
 
 	"leetcli/internal/config"
+	"leetcli/internal/lang"
 	"leetcli/internal/leetcode"
 	"leetcli/internal/store"
 	"leetcli/internal/workspace"
 )
 
 type app struct {
-	cfg   config.Config
-	paths config.Paths
-	store *store.Store
+	cfg      config.Config
+	paths    config.Paths
+	store    *store.Store
+	language lang.Language
+	// cli is shared by every request the command makes, so the client's
+	// rate limiter spaces all of them.
+	cli     *leetcode.Client
+	cliOnce sync.Once
 }
 
 func loadApp(ctx context.Context) (*app, error) {
@@ -24,6 +32,10 @@ func loadApp(ctx context.Context) (*app, error) {
 	if err != nil {
 		return nil, err
 	}
+	l, err := lang.Lookup(loaded.Config.Language)
+	if err != nil {
+		return nil, fmt.Errorf("config language: %w", err)
+	}
 	if err := workspace.EnsureBaseDirs(loaded.Config.Workspace.ProblemsDir); err != nil {
 		return nil, err
 	}
@@ -31,13 +43,47 @@ func loadApp(ctx context.Context) (*app, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &app{cfg: loaded.Config, paths: loaded.Paths, store: st}, nil
+	return &app{cfg: loaded.Config, paths: loaded.Paths, store: st, language: l}, nil
 }
 
 func (a *app) close() { _ = a.store.Close() }
 
+// lang resolves a --lang flag value, falling back to the configured default.
+func (a *app) lang(flag string) (lang.Language, error) {
+	if strings.TrimSpace(flag) == "" {
+		return a.language, nil
+	}
+	return lang.Lookup(flag)
+}
+
+// langFor resolves the language for an existing problem: the --lang flag
+// value if set, else the language the problem was prepared in, else the
+// configured default.
+func (a *app) langFor(ctx context.Context, slug, flag string) (lang.Language, error) {
+	if strings.TrimSpace(flag) != "" {
+		return lang.Lookup(flag)
+	}
+	return a.problemLang(ctx, slug), nil
+}
+
+// problemLang is the language slug was prepared in, or the configured
+// default.
+func (a *app) problemLang(ctx context.Context, slug string) lang.Language {
+	if p, err := a.store.GetProblem(ctx, slug); err == nil && p.Lang != "" {
+		if l, err := lang.Lookup(p.Lang); err == nil {
+			return l
+		}
+	}
+	return a.language
+}
+
 func (a *app) client() *leetcode.Client {
-	return leetcode.New(a.cfg.Site, a.cfg.Auth.LeetCodeSession, a.cfg.Auth.CSRFToken)
+	a.cliOnce.Do(func() {
+		a.cli = leetcode.New(a.cfg.Site, a.cfg.Auth.LeetCodeSession, a.cfg.Auth.CSRFToken)
+		a.cli.SetPollTimeout(time.Duration(a.cfg.Submit.PollTimeoutSec * float64(time.Second)))
+		a.cli.SetTranslated(a.cfg.Translate)
+	})
+	return a.cli
 }
 
 func problemSlugFromArgOrCurrent(ctx context.Context, a *app, slug string) (string, error) {
@@ -58,17 +104,170 @@ func problemSlugFromArgOrCurrent(ctx context.Context, a *app, slug string) (stri
 	return cur, nil
 }
 
-func syncMeta(ctx context.Context, a *app, slug string) error {
+// errorHint suggests a next step for errors the LeetCode client recognises.
+func errorHint(err error) string {
+	switch {
+	case errors.Is(err, leetcode.ErrUnauthorized):
+		return "Hint: your LeetCode session is missing or expired. Run `leet auth guide`, then `leet auth --cookie \"<COOKIE_HEADER>\"`."
+	case errors.Is(err, leetcode.ErrRateLimited):
+		return "Hint: LeetCode is rate limiting requests. Wait a minute and try again."
+	case errors.Is(err, leetcode.ErrPremiumOnly):
+		return "Hint: this problem needs LeetCode Premium. Try another one with `leet solve --random`."
+	case errors.Is(err, leetcode.ErrNotFound):
+		return "Hint: check the problem slug; it is the last part of the URL, e.g. two-sum in leetcode.com/problems/two-sum/."
+	}
+	return ""
+}
+
+func problemFromQuestion(q leetcode.Question) store.Problem {
+	return store.Problem{
+		FrontendID:     q.FrontendID,
+		QuestionID:     q.QuestionID,
+		Slug:           q.Slug,
+		Title:          q.Title,
+		Difficulty:     q.Difficulty,
+		Topics:         q.Topics,
+		StatementHTML:  q.StatementHTML,
+		ExampleTests:   q.ExampleTests,
+		ExampleOutputs: q.ExampleOutputs,
+		CodeStub:       q.Snippets["python3"],
+		CodeStubs:      q.Snippets,
+		Hints:          q.Hints,
+		Similar:        similarToStore(q.Similar),
+		AcRate:         q.AcRate,
+		Likes:          q.Likes,
+		Dislikes:       q.Dislikes,
+	}
+}
+
+func similarToStore(in []leetcode.SimilarQuestion) []store.SimilarProblem {
+	out := make([]store.SimilarProblem, 0, len(in))
+	for _, sq := range in {
+		out = append(out, store.SimilarProblem{Slug: sq.Slug, Title: sq.Title, Difficulty: sq.Difficulty})
+	}
+	return out
+}
+
+func questionFromProblem(p store.Problem) leetcode.Question {
+	q := leetcode.Question{
+		FrontendID:     p.FrontendID,
+		QuestionID:     p.QuestionID,
+		Slug:           p.Slug,
+		Title:          p.Title,
+		Difficulty:     p.Difficulty,
+		StatementHTML:  p.StatementHTML,
+		ExampleTests:   p.ExampleTests,
+		ExampleOutputs: p.ExampleOutputs,
+		Topics:         p.Topics,
+		Snippets:       p.CodeStubs,
+		Hints:          p.Hints,
+		AcRate:         p.AcRate,
+		Likes:          p.Likes,
+		Dislikes:       p.Dislikes,
+	}
+	for _, sp := range p.Similar {
+		q.Similar = append(q.Similar, leetcode.SimilarQuestion{Slug: sp.Slug, Title: sp.Title, Difficulty: sp.Difficulty})
+	}
+	return q
+}
+
+// prepareProblem caches q, marks it in progress and writes its workspace
+// files for l.
+func prepareProblem(ctx context.Context, a *app, q leetcode.Question, l lang.Language) (store.ProblemRow, error) {
+	return prepareProblemFiles(ctx, a, q, l, false)
+}
+
+// prepareProblemFiles is prepareProblem; with blind set the problem is hidden
+// first, so its full statement is never written.
+func prepareProblemFiles(ctx context.Context, a *app, q leetcode.Question, l lang.Language, blind bool) (store.ProblemRow, error) {
+	p := problemFromQuestion(q)
+	p.Status = "in_progress"
+	if err := a.store.UpsertProblem(ctx, p); err != nil {
+		return store.ProblemRow{}, err
+	}
+	if err := a.store.SetProblemLang(ctx, q.Slug, l.Slug); err != nil {
+		return store.ProblemRow{}, err
+	}
+	if blind {
+		if err := a.store.SetBlind(ctx, []string{q.Slug}, true); err != nil {
+			return store.ProblemRow{}, err
+		}
+	}
+	row, err := a.store.GetProblem(ctx, q.Slug)
+	if err != nil {
+		return store.ProblemRow{}, err
+	}
+	if row.Status == "todo" {
+		_ = a.store.SetProblemStatus(ctx, q.Slug, "in_progress")
+		row.Status = "in_progress"
+	}
+	if err := workspace.EnsureProblemFiles(a.cfg.Workspace.ProblemsDir, row, l); err != nil {
+		return store.ProblemRow{}, err
+	}
+	if err := workspace.WriteMetaJSON(a.cfg.Workspace.ProblemsDir, row); err != nil {
+		return store.ProblemRow{}, err
+	}
+	return row, nil
+}
+
+// submittedAt is when sub was sent to the judge, falling back to its
+// created_at (kept by SQLite in UTC), which also counts judging time.
+func submittedAt(sub store.Submission) time.Time {
+	if !sub.SentAt.IsZero() {
+		return sub.SentAt
+	}
+	at, _ := time.ParseInLocation("2006-01-02 15:04:05", sub.CreatedAt, time.UTC)
+	return at
+}
+
+func submissionFromResult(slug string, l lang.Language, code string, sent time.Time, res leetcode.SubmitResult) store.Submission {
+	return store.Submission{
+		ID:                res.SubmissionID,
+		Slug:              slug,
+		Status:            res.Status,
+		Runtime:           res.Runtime,
+		Memory:            res.Memory,
+		RuntimePercentile: res.RuntimePercentile,
+		MemoryPercentile:  res.MemoryPercentile,
+		TotalCorrect:      res.TotalCorrect,
+		TotalTestcases:    res.TotalTestcases,
+		LastTestcase:      res.LastTestcase,
+		ExpectedOutput:    res.ExpectedOutput,
+		CodeOutput:        res.CodeOutput,
+		StdOutput:         res.StdOutput,
+		CompileError:      res.CompileError,
+		RuntimeError:      res.RuntimeError,
+		Lang:              l.Slug,
+		Code:              code,
+		SentAt:            sent,
+	}
+}
+
+func syncMeta(ctx context.Context, a *app, slug string, l lang.Language) error {
 	p, err := a.store.GetProblem(ctx, slug)
 	if err != nil {
 		return err
 	}
-	if err := workspace.EnsureProblemFiles(a.cfg.Workspace.ProblemsDir, p); err != nil {
+	if err := workspace.EnsureProblemFiles(a.cfg.Workspace.ProblemsDir, p, l); err != nil {
 		return err
 	}
 	return workspace.WriteMetaJSON(a.cfg.Workspace.ProblemsDir, p)
 }
 
-func solutionPath(problemsDir, slug string) string {
-	return filepath.Join(problemsDir, slug, "solution.py")
+func solutionPath(problemsDir, slug string, l lang.Language) string {
+	return workspace.SolutionPath(problemsDir, slug, l)
+}
+
+// workingSolution is the solution file test, submit and open act on: the
+// review copy while a review of slug is open, the original otherwise. The
+// first use of the review copy starts the review's clock.
+func (a *app) workingSolution(ctx context.Context, slug string, l lang.Language) string {
+	if _, err := a.store.OpenReviewSession(ctx, slug); err == nil {
+		path := workspace.ReviewSolutionPath(a.cfg.Workspace.ProblemsDir, slug, l)
+		if _, err := os.Stat(path); err == nil {
+			_ = a.store.ActivateReviewSession(ctx, slug)
+			return path
+		}
+	}
+	return solutionPath(a.cfg.Workspace.ProblemsDir, slug, l)
 }
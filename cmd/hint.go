@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"leetcli/internal/leetcode"
+)
+
+var hintCmd = &cobra.Command{
+	Use:   "hint [slug]",
+	Short: "Reveal the next hint for a problem",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		a, err := loadApp(ctx)
+		if err != nil {
+			return err
+		}
+		defer a.close()
+
+		slug := ""
+		if len(args) == 1 {
+			slug = args[0]
+		}
+		slug, err = problemSlugFromArgOrCurrent(ctx, a, slug)
+		if err != nil {
+			return err
+		}
+		if err := interviewBlocks(ctx, a, "leet hint"); err != nil {
+			return err
+		}
+		p, err := a.store.GetProblem(ctx, slug)
+		if err != nil {
+			return fmt.Errorf("problem %s is not cached; run leet solve --slug %s first", slug, slug)
+		}
+		hints := p.Hints
+		if len(hints) == 0 {
+			// Problems cached before hints were stored: refetch once.
+			if q, qErr := a.client().Question(ctx, slug); qErr == nil && len(q.Hints) > 0 {
+				if _, err := prepareProblem(ctx, a, q, a.problemLang(ctx, slug)); err != nil {
+					return err
+				}
+				hints = q.Hints
+			}
+		}
+		if len(hints) == 0 {
+			fmt.Printf("%s has no hints\n", slug)
+			return nil
+		}
+
+		used, err := a.store.HintsUsed(ctx, slug)
+		if err != nil {
+			return err
+		}
+		for i := 0; i < min(used, len(hints)); i++ {
+			fmt.Printf("%s\n%s\n\n", mutedStyle.Render(fmt.Sprintf("Hint %d/%d", i+1, len(hints))), mutedStyle.Render(hintText(hints[i])))
+		}
+		if used >= len(hints) {
+			fmt.Printf("All %d hints revealed for %s\n", len(hints), slug)
+			return nil
+		}
+		if err := a.store.RecordHint(ctx, slug, used+1); err != nil {
+			return err
+		}
+		fmt.Printf("%s\n%s\n", passStyle.Render(fmt.Sprintf("Hint %d/%d", used+1, len(hints))), hintText(hints[used]))
+		if used+1 < len(hints) {
+			fmt.Printf("\nRun leet hint again for the next one.\n")
+		}
+		return nil
+	},
+}
+
+func hintText(h string) string {
+	return strings.TrimSpace(leetcode.PlainText(h))
+}
@@ -3,28 +3,33 @@ package cmd
 import (
 	"fmt"
 	"os"
-	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	"leetcli/internal/config"
+	"leetcli/internal/lang"
 	"leetcli/internal/store"
 	"leetcli/internal/workspace"
 )
 
 var initProjectConfig bool
+var initLang string
+var initSite string
+var initTranslate bool
 
 var initCmd = &cobra.Command{
 	Use:   "init",
 	Short: "Initialize LeetCLI workspace and config",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		cfg := config.Config{
-			Site: "https://leetcode.com",
-			Workspace: config.WorkspaceConfig{
-				ProblemsDir: "problems",
-				DBPath:      filepath.Join(".leetcli", "leetcli.db"),
-			},
+		l, err := lang.Lookup(initLang)
+		if err != nil {
+			return err
 		}
+		cfg := config.Default()
+		cfg.Language = l.Slug
+		cfg.Site = siteURL(initSite)
+		cfg.Translate = initTranslate
 
 		path, err := config.Save(cfg, initProjectConfig)
 		if err != nil {
@@ -44,6 +49,8 @@ var initCmd = &cobra.Command{
 		fmt.Printf("Config: %s\n", path)
 		fmt.Printf("DB: %s\n", cfg.Workspace.DBPath)
 		fmt.Printf("Problems dir: %s\n", cfg.Workspace.ProblemsDir)
+		fmt.Printf("Language: %s\n", l.Name)
+		fmt.Printf("Site: %s\n", cfg.Site)
 		if _, err := os.Stat(".git"); os.IsNotExist(err) {
 			fmt.Println("Hint: run git init to version your local practice workspace")
 		}
@@ -51,6 +58,21 @@ var initCmd = &cobra.Command{
 	},
 }
 
+// siteURL expands the "com" and "cn" shorthands accepted by --site.
+func siteURL(v string) string {
+	switch strings.ToLower(strings.TrimSpace(v)) {
+	case "", "com", "leetcode.com":
+		return "https://leetcode.com"
+	case "cn", "leetcode.cn":
+		return "https://leetcode.cn"
+	default:
+		return strings.TrimRight(v, "/")
+	}
+}
+
 func init() {
 	initCmd.Flags().BoolVar(&initProjectConfig, "project", false, "write project-local .leetcli/config.yaml instead of XDG config")
+	initCmd.Flags().StringVar(&initLang, "lang", lang.Default, "default solution language ("+strings.Join(lang.Slugs(), ", ")+")")
+	initCmd.Flags().StringVar(&initSite, "site", "com", "LeetCode site: com, cn or a base URL")
+	initCmd.Flags().BoolVar(&initTranslate, "translate", false, "prefer translated titles and statements (leetcode.cn)")
 }
@@ -0,0 +1,365 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"leetcli/internal/interview"
+	"leetcli/internal/store"
+	"leetcli/internal/workspace"
+)
+
+var interviewDuration int
+var interviewProblems int
+var interviewDifficulty string
+var interviewLang string
+var interviewOut string
+
+var interviewCmd = &cobra.Command{
+	Use:   "interview",
+	Short: "Start a timed mock interview on unseen problems",
+	Long: `Start a timed mock interview on unseen problems.
+
+Difficulty, topics and hints are hidden until the interview ends, leet hint
+and leet test --remote are disabled, and submissions are refused once the
+countdown runs out. Finish with leet interview end to get a scored report.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		a, err := loadApp(ctx)
+		if err != nil {
+			return err
+		}
+		defer a.close()
+
+		if iv, ok := a.runningInterview(ctx); ok {
+			return fmt.Errorf("interview #%d is still running; see leet interview status or finish it with leet interview end", iv.ID)
+		}
+		if interviewDuration <= 0 || interviewProblems <= 0 {
+			return fmt.Errorf("--duration and --problems must be positive")
+		}
+		l, err := a.lang(interviewLang)
+		if err != nil {
+			return err
+		}
+		offline, err := ensureCatalog(ctx, a)
+		if err != nil {
+			return err
+		}
+		if offline {
+			return fmt.Errorf("a mock interview needs LeetCode to be reachable")
+		}
+		picks, err := a.store.RandomCatalog(ctx, store.CatalogFilter{Difficulty: interviewDifficulty, Unseen: true}, interviewProblems*4)
+		if err != nil {
+			return err
+		}
+		cli := a.client()
+		rows := make([]store.ProblemRow, 0, interviewProblems)
+		slugs := make([]string, 0, interviewProblems)
+		started := false
+		defer func() {
+			// Picks of an interview that failed to start get their full
+			// statements back.
+			if !started && len(slugs) > 0 {
+				_ = a.store.SetBlind(ctx, slugs, false)
+				_ = rewriteProblemDocs(ctx, a, slugs)
+			}
+		}()
+		for _, pick := range picks {
+			if len(rows) == interviewProblems {
+				break
+			}
+			q, err := cli.Question(ctx, pick.Slug)
+			if err != nil {
+				continue
+			}
+			row, err := prepareProblemFiles(ctx, a, q, l, true)
+			if err != nil {
+				return err
+			}
+			rows = append(rows, row)
+			slugs = append(slugs, row.Slug)
+		}
+		if len(rows) < interviewProblems {
+			return fmt.Errorf("found only %d unseen problem(s) for the requested difficulty", len(rows))
+		}
+
+		iv, err := a.store.StartInterview(ctx, interviewDuration, interviewDifficulty, slugs)
+		if err != nil {
+			return err
+		}
+		started = true
+		_ = a.store.SetCurrentProblem(ctx, slugs[0])
+		// Like a contest, the countdown is a timer that timer watch falls
+		// back to for the interview's problems.
+		if err := a.store.StartTimer(ctx, iv.TimerSlug(), iv.DurationMin, false); err != nil {
+			return err
+		}
+
+		fmt.Printf("Interview #%d: %d problem(s), %d minutes, ends at %s\n\n", iv.ID, len(slugs), iv.DurationMin, iv.Deadline().Format("15:04"))
+		for i, r := range rows {
+			fmt.Printf("%d. %s  %s\n", i+1, r.Title, mutedStyle.Render(workspace.ProblemDir(a.cfg.Workspace.ProblemsDir, r.Slug)))
+		}
+		fmt.Println("\nHints and test --remote are disabled. Check the clock with leet interview status or leet timer watch; finish with leet interview end.")
+		return nil
+	},
+}
+
+var interviewStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the remaining time and progress of the running interview",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		a, err := loadApp(ctx)
+		if err != nil {
+			return err
+		}
+		defer a.close()
+
+		iv, ok := a.runningInterview(ctx)
+		if !ok {
+			fmt.Println("No interview running. Start one with leet interview --duration 45 --problems 2")
+			return nil
+		}
+		left := time.Until(iv.Deadline())
+		if left <= 0 {
+			fmt.Printf("Time is up for interview #%d.\n", iv.ID)
+			return endInterview(ctx, a, iv)
+		}
+		fmt.Printf("Interview #%d: %s left (ends at %s)\n", iv.ID, left.Round(time.Second), iv.Deadline().Format("15:04"))
+		rep, err := interviewReport(ctx, a, iv)
+		if err != nil {
+			return err
+		}
+		for i, p := range rep.Problems {
+			state := mutedStyle.Render("open")
+			switch {
+			case p.Solved:
+				state = passStyle.Render("accepted")
+			case p.Rejected > 0:
+				state = failStyle.Render(fmt.Sprintf("%d rejected", p.Rejected))
+			case p.LastTestPassed:
+				state = "local tests passing"
+			}
+			fmt.Printf("%d. %-40s %s\n", i+1, truncate(p.Title, 40), state)
+		}
+		return nil
+	},
+}
+
+var interviewEndCmd = &cobra.Command{
+	Use:   "end",
+	Short: "Finish the running interview and write its scored report",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		a, err := loadApp(ctx)
+		if err != nil {
+			return err
+		}
+		defer a.close()
+
+		iv, ok := a.runningInterview(ctx)
+		if !ok {
+			return fmt.Errorf("no interview running")
+		}
+		return endInterview(ctx, a, iv)
+	},
+}
+
+var interviewReportCmd = &cobra.Command{
+	Use:   "report [id]",
+	Short: "Print or export the report of a finished interview (defaults to the latest)",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		a, err := loadApp(ctx)
+		if err != nil {
+			return err
+		}
+		defer a.close()
+
+		var iv store.Interview
+		if len(args) == 1 {
+			id, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid interview id %q", args[0])
+			}
+			if iv, err = a.store.GetInterview(ctx, id); err != nil {
+				return fmt.Errorf("interview %d not found", id)
+			}
+		} else {
+			all, err := a.store.ListInterviews(ctx)
+			if err != nil {
+				return err
+			}
+			for _, candidate := range all {
+				if !candidate.FinishedAt.IsZero() {
+					iv = candidate
+					break
+				}
+			}
+			if iv.ID == 0 {
+				return fmt.Errorf("no finished interviews yet")
+			}
+		}
+		if iv.FinishedAt.IsZero() {
+			return fmt.Errorf("interview #%d is still running", iv.ID)
+		}
+		if interviewOut != "" {
+			if err := os.WriteFile(interviewOut, []byte(iv.Report), 0o644); err != nil {
+				return fmt.Errorf("write report: %w", err)
+			}
+			fmt.Printf("Wrote %s\n", interviewOut)
+			return nil
+		}
+		fmt.Print(renderMarkdown(iv.Report, showWidth()))
+		return nil
+	},
+}
+
+var interviewListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List past interviews with their scores",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		a, err := loadApp(ctx)
+		if err != nil {
+			return err
+		}
+		defer a.close()
+
+		all, err := a.store.ListInterviews(ctx)
+		if err != nil {
+			return err
+		}
+		if len(all) == 0 {
+			fmt.Println("No interviews yet")
+			return nil
+		}
+		for _, iv := range all {
+			result := failStyle.Render("running")
+			if !iv.FinishedAt.IsZero() {
+				result = fmt.Sprintf("%3d/100  %s", iv.Score, interview.Verdict(iv.Score))
+			}
+			fmt.Printf("#%-4d %s  %3d min  %-6s %d problem(s)  %s\n", iv.ID, iv.StartedAt.Format("2006-01-02 15:04"), iv.DurationMin, blankAsDash(iv.Difficulty), len(iv.Slugs), result)
+		}
+		return nil
+	},
+}
+
+// runningInterview returns the unfinished interview, if any.
+func (a *app) runningInterview(ctx context.Context) (store.Interview, bool) {
+	iv, err := a.store.ActiveInterview(ctx)
+	if err != nil {
+		return store.Interview{}, false
+	}
+	return iv, true
+}
+
+// rewriteProblemDocs re-renders README.md and meta.json of slugs, after
+// their visibility changed.
+func rewriteProblemDocs(ctx context.Context, a *app, slugs []string) error {
+	for _, slug := range slugs {
+		p, err := a.store.GetProblem(ctx, slug)
+		if err != nil {
+			return err
+		}
+		if err := workspace.WriteReadme(a.cfg.Workspace.ProblemsDir, p); err != nil {
+			return err
+		}
+		if err := workspace.WriteMetaJSON(a.cfg.Workspace.ProblemsDir, p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// interviewReport collects each problem's result from the submissions and
+// local test runs made since the interview started.
+func interviewReport(ctx context.Context, a *app, iv store.Interview) (interview.Report, error) {
+	rep := interview.Report{
+		ID:        iv.ID,
+		StartedAt: iv.StartedAt,
+		Duration:  time.Duration(iv.DurationMin) * time.Minute,
+		Used:      min(time.Since(iv.StartedAt), time.Duration(iv.DurationMin)*time.Minute),
+	}
+	for _, slug := range iv.Slugs {
+		pr := interview.ProblemResult{Slug: slug, Title: slug}
+		if p, err := a.store.GetProblem(ctx, slug); err == nil {
+			pr.Title, pr.Difficulty = p.Title, p.Difficulty
+		}
+		subs, err := a.store.SubmissionsSince(ctx, slug, iv.StartedAt)
+		if err != nil {
+			return rep, err
+		}
+		judged := make([]interview.Submission, 0, len(subs))
+		for _, sub := range subs {
+			judged = append(judged, interview.Submission{At: submittedAt(sub).Sub(iv.StartedAt), Status: sub.Status, Runtime: sub.Runtime, Memory: sub.Memory})
+		}
+		pr = interview.Judge(pr, judged, rep.Duration)
+		if pr.TestRuns, pr.LastTestPassed, err = a.store.TestRunsSince(ctx, slug, iv.StartedAt); err != nil {
+			return rep, err
+		}
+		rep.Problems = append(rep.Problems, pr)
+	}
+	return interview.Build(rep), nil
+}
+
+// endInterview scores iv, stores the report, exports it as markdown and
+// restores the problems' full READMEs.
+func endInterview(ctx context.Context, a *app, iv store.Interview) error {
+	rep, err := interviewReport(ctx, a, iv)
+	if err != nil {
+		return err
+	}
+	md := rep.Markdown()
+	if err := a.store.FinishInterview(ctx, iv.ID, rep.Score, md); err != nil {
+		return err
+	}
+	if err := rewriteProblemDocs(ctx, a, iv.Slugs); err != nil {
+		return err
+	}
+	if _, err := a.store.StopTimer(ctx, iv.TimerSlug()); err != nil {
+		return err
+	}
+	path := filepath.Join(filepath.Dir(filepath.Clean(a.cfg.Workspace.ProblemsDir)), "interviews", fmt.Sprintf("%s-%d.md", iv.StartedAt.Format("2006-01-02"), iv.ID))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create interviews dir: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(md), 0o644); err != nil {
+		return fmt.Errorf("write report: %w", err)
+	}
+	fmt.Print(renderMarkdown(md, showWidth()))
+	fmt.Printf("\nReport saved to %s\n", path)
+	return nil
+}
+
+// interviewBlocks reports an error when action is not allowed because a mock
+// interview is running.
+func interviewBlocks(ctx context.Context, a *app, action string) error {
+	if iv, ok := a.runningInterview(ctx); ok {
+		return fmt.Errorf("%s is disabled during mock interview #%d (leet interview end finishes it)", action, iv.ID)
+	}
+	return nil
+}
+
+func init() {
+	interviewCmd.Flags().IntVar(&interviewDuration, "duration", 45, "session length in minutes")
+	interviewCmd.Flags().IntVar(&interviewProblems, "problems", 2, "number of problems")
+	interviewCmd.Flags().StringVar(&interviewDifficulty, "difficulty", "", "problem difficulty (Easy/Medium/Hard)")
+	interviewCmd.Flags().StringVar(&interviewLang, "lang", "", "solution language (defaults to config language)")
+	interviewReportCmd.Flags().StringVar(&interviewOut, "out", "", "write the markdown report to this file instead of printing it")
+	interviewCmd.AddCommand(interviewStatusCmd)
+	interviewCmd.AddCommand(interviewEndCmd)
+	interviewCmd.AddCommand(interviewReportCmd)
+	interviewCmd.AddCommand(interviewListCmd)
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"leetcli/internal/config"
+	"leetcli/internal/lang"
+	"leetcli/internal/store"
+	"leetcli/internal/workspace"
+)
+
+func TestPrepareBlindProblem(t *testing.T) {
+	srv := startFake(t)
+	ctx := context.Background()
+	st, err := store.Open(filepath.Join(t.TempDir(), "leetcli.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Close()
+	a := &app{cfg: config.Default(), store: st}
+	a.cfg.Workspace.ProblemsDir = t.TempDir()
+
+	q, err := srv.Client().Question(ctx, "two-sum")
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, err := lang.Lookup("python3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := prepareProblemFiles(ctx, a, q, l, true); err != nil {
+		t.Fatal(err)
+	}
+	dir := workspace.ProblemDir(a.cfg.Workspace.ProblemsDir, "two-sum")
+	for _, name := range []string{"README.md", "meta.json"} {
+		b, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(string(b), "Easy") {
+			t.Errorf("blind %s shows the difficulty:\n%s", name, b)
+		}
+	}
+}
+
+func TestInterviewClock(t *testing.T) {
+	startFake(t)
+
+	if _, err := runLeet(t, "interview", "--duration", "30", "--problems", "1", "--lang", "python3"); err != nil {
+		t.Fatalf("interview: %v", err)
+	}
+	ctx := context.Background()
+	a, err := loadApp(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	iv, ok := a.runningInterview(ctx)
+	if !ok {
+		a.close()
+		t.Fatal("no interview running after leet interview")
+	}
+	tm, err := a.store.ActiveTimer(ctx, iv.TimerSlug())
+	readme := filepath.Join(workspace.ProblemDir(a.cfg.Workspace.ProblemsDir, iv.Slugs[0]), "README.md")
+	a.close()
+	if err != nil {
+		t.Fatalf("interview timer: %v", err)
+	}
+	if tm.TargetMinutes != 30 {
+		t.Errorf("interview timer target = %d minutes, want 30", tm.TargetMinutes)
+	}
+	if b, _ := os.ReadFile(readme); strings.Contains(string(b), "Difficulty:") {
+		t.Errorf("README shows the difficulty during the interview:\n%s", b)
+	}
+
+	if _, err := runLeet(t, "interview", "end"); err != nil {
+		t.Fatalf("interview end: %v", err)
+	}
+	if b, _ := os.ReadFile(readme); !strings.Contains(string(b), "Difficulty:") {
+		t.Errorf("README not restored after the interview:\n%s", b)
+	}
+	a, err = loadApp(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer a.close()
+	if _, err := a.store.ActiveTimer(ctx, iv.TimerSlug()); err == nil {
+		t.Error("interview timer still running after interview end")
+	}
+}
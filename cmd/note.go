@@ -55,7 +55,7 @@ var noteCmd = &cobra.Command{
 			_, _ = f.WriteString(line)
 			_ = f.Close()
 		}
-		_ = syncMeta(ctx, a, slug)
+		_ = syncMeta(ctx, a, slug, a.problemLang(ctx, slug))
 		fmt.Printf("Saved note for %s\n", slug)
 		return nil
 	},
@@ -11,10 +11,11 @@ import (
 )
 
 var openDir bool
+var openLang string
 
 var openCmd = &cobra.Command{
 	Use:   "open [slug]",
-	Short: "Open solution.py in $EDITOR (or full problem directory)",
+	Short: "Open the solution file in $EDITOR (or full problem directory)",
 	Args:  cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := context.Background()
@@ -32,10 +33,14 @@ var openCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		if err := syncMeta(ctx, a, slug); err != nil {
+		l, err := a.langFor(ctx, slug, openLang)
+		if err != nil {
+			return err
+		}
+		if err := syncMeta(ctx, a, slug, l); err != nil {
 			return err
 		}
-		path := solutionPath(a.cfg.Workspace.ProblemsDir, slug)
+		path := a.workingSolution(ctx, slug, l)
 		if openDir {
 			path = filepath.Join(a.cfg.Workspace.ProblemsDir, slug)
 		}
@@ -45,5 +50,6 @@ var openCmd = &cobra.Command{
 }
 
 func init() {
-	openCmd.Flags().BoolVar(&openDir, "dir", false, "open full problem directory instead of solution.py")
+	openCmd.Flags().BoolVar(&openDir, "dir", false, "open full problem directory instead of the solution file")
+	openCmd.Flags().StringVar(&openLang, "lang", "", "solution language (defaults to the language the problem was solved in)")
 }
@@ -0,0 +1,256 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"leetcli/internal/plans"
+)
+
+var planCount int
+var planShowProblems bool
+
+var planCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Work through a curated study plan (Blind 75, NeetCode 150, Grind 169 or your own)",
+}
+
+var planListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List bundled and custom study plans with progress",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		a, err := loadApp(ctx)
+		if err != nil {
+			return err
+		}
+		defer a.close()
+
+		all, err := plans.Load(a.paths.PlansDir)
+		if err != nil {
+			return err
+		}
+		statuses, err := a.store.ProblemStatuses(ctx)
+		if err != nil {
+			return err
+		}
+		active, _ := a.store.ActivePlan(ctx)
+		for _, p := range all {
+			mark := " "
+			if p.ID == active {
+				mark = "*"
+			}
+			solved, _, total := planProgress(p.Slugs(), statuses)
+			kind := ""
+			if p.Custom {
+				kind = mutedStyle.Render(" (custom)")
+			}
+			fmt.Printf("%s %-14s %-16s %3d/%-3d%s\n", mark, p.ID, truncate(p.Name, 16), solved, total, kind)
+		}
+		fmt.Println(mutedStyle.Render("Custom plans: YAML or JSON files in " + a.paths.PlansDir))
+		return nil
+	},
+}
+
+var planStartCmd = &cobra.Command{
+	Use:   "start <plan>",
+	Short: "Make a plan the active one",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		a, err := loadApp(ctx)
+		if err != nil {
+			return err
+		}
+		defer a.close()
+
+		p, err := loadPlan(ctx, a, args[0])
+		if err != nil {
+			return err
+		}
+		if err := a.store.SetActivePlan(ctx, p.ID); err != nil {
+			return err
+		}
+		statuses, err := a.store.ProblemStatuses(ctx)
+		if err != nil {
+			return err
+		}
+		solved, _, total := planProgress(p.Slugs(), statuses)
+		fmt.Printf("Active plan: %s (%d problems in %d sections, %d already solved)\n", p.Name, total, len(p.Sections), solved)
+		if rest := planRemaining(p, statuses); len(rest) > 0 {
+			fmt.Printf("Next: %s (%s)\n", rest[0].Slug, rest[0].Section)
+			fmt.Println("Start it: leet solve --plan")
+		}
+		return nil
+	},
+}
+
+var planNextCmd = &cobra.Command{
+	Use:   "next",
+	Short: "Show the next unsolved problems of the active plan",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		a, err := loadApp(ctx)
+		if err != nil {
+			return err
+		}
+		defer a.close()
+
+		p, err := loadPlan(ctx, a, "")
+		if err != nil {
+			return err
+		}
+		statuses, err := a.store.ProblemStatuses(ctx)
+		if err != nil {
+			return err
+		}
+		rest := planRemaining(p, statuses)
+		if len(rest) == 0 {
+			fmt.Printf("Every problem in %s is solved.\n", p.Name)
+			return nil
+		}
+		for i, it := range rest {
+			if i == planCount {
+				break
+			}
+			state := ""
+			if statuses[it.Slug] == "in_progress" {
+				state = failStyle.Render(" attempted")
+			}
+			fmt.Printf("%3d. %-48s %s%s\n", it.Index+1, it.Slug, mutedStyle.Render(it.Section), state)
+		}
+		fmt.Println("Start it: leet solve --plan")
+		return nil
+	},
+}
+
+var planStatusCmd = &cobra.Command{
+	Use:   "status [plan]",
+	Short: "Show per-section progress of a plan (defaults to the active one)",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		a, err := loadApp(ctx)
+		if err != nil {
+			return err
+		}
+		defer a.close()
+
+		id := ""
+		if len(args) == 1 {
+			id = args[0]
+		}
+		p, err := loadPlan(ctx, a, id)
+		if err != nil {
+			return err
+		}
+		statuses, err := a.store.ProblemStatuses(ctx)
+		if err != nil {
+			return err
+		}
+		solved, attempted, total := planProgress(p.Slugs(), statuses)
+		fmt.Printf("%s  %s\n", showTitleStyle.Render(p.Name), mutedStyle.Render(p.Description))
+		fmt.Printf("%s %d/%d solved, %d attempted\n\n", progressBar(solved, total, 30), solved, total, attempted)
+		for _, s := range p.Sections {
+			ss, sa, st := planProgress(s.Slugs, statuses)
+			fmt.Printf("%-28s %s %3d/%-3d", truncate(s.Name, 28), progressBar(ss, st, 20), ss, st)
+			if sa > 0 {
+				fmt.Print(mutedStyle.Render(fmt.Sprintf("  %d attempted", sa)))
+			}
+			fmt.Println()
+			if !planShowProblems {
+				continue
+			}
+			for _, slug := range s.Slugs {
+				switch statuses[slug] {
+				case "solved":
+					fmt.Printf("  %s %s\n", passStyle.Render("✓"), slug)
+				case "in_progress":
+					fmt.Printf("  %s %s\n", failStyle.Render("~"), slug)
+				default:
+					fmt.Printf("  %s %s\n", mutedStyle.Render("·"), mutedStyle.Render(slug))
+				}
+			}
+		}
+		return nil
+	},
+}
+
+// planItem is a problem of a plan with its position in the plan's order.
+type planItem struct {
+	Index   int
+	Slug    string
+	Section string
+}
+
+// loadPlan finds plan id among the bundled and custom plans, or the active
+// plan when id is empty.
+func loadPlan(ctx context.Context, a *app, id string) (plans.Plan, error) {
+	if strings.TrimSpace(id) == "" {
+		active, err := a.store.ActivePlan(ctx)
+		if err != nil {
+			return plans.Plan{}, err
+		}
+		if active == "" {
+			return plans.Plan{}, fmt.Errorf("no active study plan; run leet plan start <plan> (see leet plan list)")
+		}
+		id = active
+	}
+	all, err := plans.Load(a.paths.PlansDir)
+	if err != nil {
+		return plans.Plan{}, err
+	}
+	return plans.Find(all, id)
+}
+
+// planRemaining lists the plan's unsolved problems in plan order.
+func planRemaining(p plans.Plan, statuses map[string]string) []planItem {
+	out := make([]planItem, 0)
+	seen := map[string]bool{}
+	for _, s := range p.Sections {
+		for _, slug := range s.Slugs {
+			if seen[slug] {
+				continue
+			}
+			seen[slug] = true
+			if statuses[slug] != "solved" {
+				out = append(out, planItem{Index: len(seen) - 1, Slug: slug, Section: s.Name})
+			}
+		}
+	}
+	return out
+}
+
+func planProgress(slugs []string, statuses map[string]string) (solved, attempted, total int) {
+	for _, slug := range slugs {
+		switch statuses[slug] {
+		case "solved":
+			solved++
+		case "in_progress":
+			attempted++
+		}
+	}
+	return solved, attempted, len(slugs)
+}
+
+func progressBar(done, total, width int) string {
+	filled := 0
+	if total > 0 {
+		filled = done * width / total
+	}
+	return passStyle.Render(strings.Repeat("█", filled)) + mutedStyle.Render(strings.Repeat("░", width-filled))
+}
+
+func init() {
+	planNextCmd.Flags().IntVar(&planCount, "count", 5, "number of upcoming problems to show")
+	planStatusCmd.Flags().BoolVar(&planShowProblems, "problems", false, "list every problem with its status")
+	planCmd.AddCommand(planListCmd)
+	planCmd.AddCommand(planStartCmd)
+	planCmd.AddCommand(planNextCmd)
+	planCmd.AddCommand(planStatusCmd)
+}
@@ -0,0 +1,254 @@
+package cmd
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"leetcli/internal/srs"
+	"leetcli/internal/store"
+	"leetcli/internal/workspace"
+)
+
+var reviewLimit int
+var reviewLang string
+var reviewGrade int
+var reviewGiveUp bool
+
+var reviewCmd = &cobra.Command{
+	Use:   "review",
+	Short: "Prepare today's spaced-repetition reviews in fresh review copies",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		a, err := loadApp(ctx)
+		if err != nil {
+			return err
+		}
+		defer a.close()
+
+		if _, err := a.lang(reviewLang); err != nil {
+			return err
+		}
+		due, err := a.store.ListReviews(ctx, today())
+		if err != nil {
+			return err
+		}
+		if len(due) == 0 {
+			all, err := a.store.ListReviews(ctx, "")
+			if err != nil {
+				return err
+			}
+			if len(all) == 0 {
+				fmt.Println("Review queue is empty; solved problems are added automatically.")
+				return nil
+			}
+			fmt.Printf("Nothing due today. Next review: %s on %s\n", all[0].Slug, all[0].DueDate)
+			return nil
+		}
+		if reviewLimit > 0 && len(due) > reviewLimit {
+			due = due[:reviewLimit]
+		}
+
+		prepared := 0
+		for _, r := range due {
+			l, _ := a.langFor(ctx, r.Slug, reviewLang)
+			p, err := a.store.GetProblem(ctx, r.Slug)
+			if err != nil || p.StatementHTML == "" {
+				q, qErr := a.client().Question(ctx, r.Slug)
+				if qErr != nil {
+					fmt.Printf("skip %s: %v\n", r.Slug, qErr)
+					continue
+				}
+				if p, err = prepareProblem(ctx, a, q, l); err != nil {
+					return err
+				}
+			}
+			path, err := workspace.PrepareReview(a.cfg.Workspace.ProblemsDir, p, l)
+			if err != nil {
+				return err
+			}
+			if err := a.store.StartReviewSession(ctx, r.Slug); err != nil {
+				return err
+			}
+			if prepared == 0 {
+				_ = a.store.SetCurrentProblem(ctx, r.Slug)
+			}
+			prepared++
+			fmt.Printf("%-32s due %s  %s\n", r.Slug, r.DueDate, mutedStyle.Render(path))
+		}
+		if prepared == 0 {
+			return fmt.Errorf("no reviews prepared")
+		}
+		fmt.Printf("\nPrepared %d review(s). test/submit/open use the review copy until the review is graded.\n", prepared)
+		fmt.Println("Each review is timed from when you first open or test its review copy.")
+		fmt.Println("An accepted submission grades the review; use leet review done to grade it yourself.")
+		return nil
+	},
+}
+
+var reviewListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Show the review queue",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		a, err := loadApp(ctx)
+		if err != nil {
+			return err
+		}
+		defer a.close()
+
+		all, err := a.store.ListReviews(ctx, "")
+		if err != nil {
+			return err
+		}
+		if len(all) == 0 {
+			fmt.Println("Review queue is empty")
+			return nil
+		}
+		fmt.Printf("%-32s %-10s %5s %5s %5s  %s\n", "SLUG", "DUE", "REPS", "DAYS", "EASE", "LAST GRADE")
+		for _, r := range all {
+			due := r.DueDate
+			if due <= today() {
+				due = failStyle.Render(fmt.Sprintf("%-10s", due))
+			} else {
+				due = fmt.Sprintf("%-10s", due)
+			}
+			grade := "-"
+			if r.LastGrade >= 0 {
+				grade = fmt.Sprintf("%d/5", r.LastGrade)
+			}
+			fmt.Printf("%-32s %s %5d %5d %5.2f  %s\n", truncate(r.Slug, 32), due, r.Repetitions, r.IntervalDays, r.Ease, grade)
+		}
+		return nil
+	},
+}
+
+var reviewAddCmd = &cobra.Command{
+	Use:   "add <slug>...",
+	Short: "Add problems to the review queue, due today",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		a, err := loadApp(ctx)
+		if err != nil {
+			return err
+		}
+		defer a.close()
+
+		for _, slug := range args {
+			if err := a.store.SetReviewDue(ctx, slug, time.Now()); err != nil {
+				return err
+			}
+			fmt.Printf("Queued %s for review\n", slug)
+		}
+		return nil
+	},
+}
+
+var reviewDoneCmd = &cobra.Command{
+	Use:   "done [slug]",
+	Short: "Grade an open review (computed from time, failures and hints unless --grade is set)",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		a, err := loadApp(ctx)
+		if err != nil {
+			return err
+		}
+		defer a.close()
+
+		slug := ""
+		if len(args) == 1 {
+			slug = args[0]
+		}
+		slug, err = problemSlugFromArgOrCurrent(ctx, a, slug)
+		if err != nil {
+			return err
+		}
+		if _, err := a.store.OpenReviewSession(ctx, slug); errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("no open review for %s; run leet review first", slug)
+		}
+		if reviewGrade > 5 || (reviewGrade < 0 && cmd.Flags().Changed("grade")) {
+			return fmt.Errorf("--grade must be between 0 and 5")
+		}
+		return finishOpenReview(ctx, a, slug, !reviewGiveUp, reviewGrade)
+	},
+}
+
+// finishOpenReview grades the open review of slug, if there is one, and
+// reschedules it. A negative override means the grade is computed.
+func finishOpenReview(ctx context.Context, a *app, slug string, solved bool, override int) error {
+	rs, err := a.store.OpenReviewSession(ctx, slug)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	r, err := a.store.GetReview(ctx, slug)
+	if errors.Is(err, sql.ErrNoRows) {
+		if err := a.store.EnrollReview(ctx, slug, time.Now()); err != nil {
+			return err
+		}
+		r, err = a.store.GetReview(ctx, slug)
+	}
+	if err != nil {
+		return err
+	}
+	hints, err := a.store.HintsUsed(ctx, slug)
+	if err != nil {
+		return err
+	}
+	elapsed := time.Since(rs.StartedAt)
+	grade := override
+	if grade < 0 {
+		grade = srs.Grade(srs.Attempt{
+			Elapsed:  elapsed,
+			Baseline: time.Duration(r.BaselineSec) * time.Second,
+			Failures: rs.Failures,
+			Hints:    hints - rs.HintsBefore,
+			Solved:   solved,
+		})
+	}
+	card := srs.Card{Repetitions: r.Repetitions, IntervalDays: r.IntervalDays, Ease: r.Ease}.Next(grade)
+	next := reviewFromCard(r, card, grade)
+	if err := a.store.UpdateReview(ctx, next); err != nil {
+		return err
+	}
+	if err := a.store.FinishReviewSession(ctx, rs.ID, grade, int(elapsed.Seconds())); err != nil {
+		return err
+	}
+	fmt.Printf("Review of %s graded %d/5 (%s, %d failure(s), %d hint(s)). Next review %s (in %d day(s)).\n",
+		slug, grade, elapsed.Round(time.Second), rs.Failures, hints-rs.HintsBefore, next.DueDate, card.IntervalDays)
+	return nil
+}
+
+func reviewFromCard(r store.Review, c srs.Card, grade int) store.Review {
+	r.Repetitions = c.Repetitions
+	r.IntervalDays = c.IntervalDays
+	r.Ease = c.Ease
+	r.LastGrade = grade
+	r.DueDate = c.Due(time.Now()).Format("2006-01-02")
+	return r
+}
+
+// today is the local date in the YYYY-MM-DD form review due dates use.
+func today() string {
+	return time.Now().Format("2006-01-02")
+}
+
+func init() {
+	reviewCmd.Flags().IntVar(&reviewLimit, "limit", 5, "maximum number of due reviews to prepare")
+	reviewCmd.Flags().StringVar(&reviewLang, "lang", "", "solution language (defaults to the language each problem was solved in)")
+	reviewDoneCmd.Flags().IntVar(&reviewGrade, "grade", -1, "grade the review yourself (0-5)")
+	reviewDoneCmd.Flags().BoolVar(&reviewGiveUp, "give-up", false, "record the review as not solved (grade 0)")
+	reviewCmd.AddCommand(reviewListCmd)
+	reviewCmd.AddCommand(reviewAddCmd)
+	reviewCmd.AddCommand(reviewDoneCmd)
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReviewDoneGrade(t *testing.T) {
+	startFake(t)
+
+	for _, args := range [][]string{
+		{"solve", "--slug", "two-sum", "--no-timer", "--lang", "python3"},
+		{"review", "add", "two-sum"},
+		{"review"},
+	} {
+		if _, err := runLeet(t, args...); err != nil {
+			t.Fatalf("%v: %v", args, err)
+		}
+	}
+	for _, g := range []string{"-2", "-1", "6"} {
+		if _, err := runLeet(t, "review", "done", "two-sum", "--grade", g); err == nil {
+			t.Errorf("review done --grade %s succeeded", g)
+		}
+	}
+	out, err := runLeet(t, "review", "done", "two-sum", "--grade", "4")
+	if err != nil {
+		t.Fatalf("review done --grade 4: %v", err)
+	}
+	if !strings.Contains(out, "graded 4/5") {
+		t.Errorf("review done output = %q, want graded 4/5", out)
+	}
+}
@@ -15,6 +15,9 @@ var rootCmd = &cobra.Command{
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
+		if hint := errorHint(err); hint != "" {
+			fmt.Fprintln(os.Stderr, hint)
+		}
 		os.Exit(1)
 	}
 }
@@ -23,12 +26,22 @@ func init() {
 	rootCmd.AddCommand(initCmd)
 	rootCmd.AddCommand(authCmd)
 	rootCmd.AddCommand(solveCmd)
+	rootCmd.AddCommand(dailyCmd)
+	rootCmd.AddCommand(catalogCmd)
 	rootCmd.AddCommand(browseCmd)
 	rootCmd.AddCommand(openCmd)
+	rootCmd.AddCommand(showCmd)
+	rootCmd.AddCommand(hintCmd)
 	rootCmd.AddCommand(testCmd)
 	rootCmd.AddCommand(submitCmd)
+	rootCmd.AddCommand(submissionsCmd)
+	rootCmd.AddCommand(syncCmd)
 	rootCmd.AddCommand(noteCmd)
 	rootCmd.AddCommand(timerCmd)
 	rootCmd.AddCommand(fetchCmd)
 	rootCmd.AddCommand(statsCmd)
+	rootCmd.AddCommand(reviewCmd)
+	rootCmd.AddCommand(planCmd)
+	rootCmd.AddCommand(interviewCmd)
+	rootCmd.AddCommand(contestCmd)
 }
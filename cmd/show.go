@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/term"
+	"github.com/spf13/cobra"
+
+	"leetcli/internal/store"
+	"leetcli/internal/workspace"
+)
+
+var showCmd = &cobra.Command{
+	Use:   "show [slug]",
+	Short: "Render a problem statement in the terminal",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		a, err := loadApp(ctx)
+		if err != nil {
+			return err
+		}
+		defer a.close()
+
+		slug := ""
+		if len(args) == 1 {
+			slug = args[0]
+		}
+		slug, err = problemSlugFromArgOrCurrent(ctx, a, slug)
+		if err != nil {
+			return err
+		}
+		p, err := a.store.GetProblem(ctx, slug)
+		if err != nil || p.StatementHTML == "" {
+			q, qErr := a.client().Question(ctx, slug)
+			if qErr != nil {
+				return qErr
+			}
+			p = store.ProblemRow{Problem: problemFromQuestion(q)}
+		}
+		if p.Blind {
+			p.Difficulty, p.Topics, p.AcRate, p.Likes, p.Dislikes = "", nil, 0, 0, 0
+			p.Similar, p.Hints = nil, nil
+		}
+		fmt.Print(renderProblem(p.Problem, showWidth()))
+		return nil
+	},
+}
+
+var (
+	showTitleStyle = lipgloss.NewStyle().Bold(true)
+	showCodeStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B"))
+	showBlockStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#D1D5DB")).
+			BorderStyle(lipgloss.NormalBorder()).BorderLeft(true).
+			BorderForeground(lipgloss.Color("#6B7280")).PaddingLeft(1)
+	difficultyStyles = map[string]lipgloss.Style{
+		"Easy":   lipgloss.NewStyle().Foreground(lipgloss.Color("#22C55E")),
+		"Medium": lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B")),
+		"Hard":   lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444")),
+	}
+
+	mdInlineRe = regexp.MustCompile("`[^`]+`|\\*\\*[^*]+\\*\\*|\\*[^*\\s][^*]*\\*")
+)
+
+func renderProblem(p store.Problem, width int) string {
+	var b strings.Builder
+	title := p.Title
+	if p.FrontendID != "" {
+		title = p.FrontendID + ". " + title
+	}
+	b.WriteString(showTitleStyle.Render(title) + "\n")
+	meta := make([]string, 0, 4)
+	if p.Difficulty != "" {
+		meta = append(meta, difficultyStyles[p.Difficulty].Render(p.Difficulty))
+	}
+	if len(p.Topics) > 0 {
+		meta = append(meta, mutedStyle.Render(strings.Join(p.Topics, ", ")))
+	}
+	if p.AcRate > 0 {
+		meta = append(meta, mutedStyle.Render(fmt.Sprintf("%.1f%% accepted", p.AcRate)))
+	}
+	if p.Likes > 0 || p.Dislikes > 0 {
+		meta = append(meta, mutedStyle.Render(fmt.Sprintf("▲ %d ▼ %d", p.Likes, p.Dislikes)))
+	}
+	if len(meta) > 0 {
+		b.WriteString(strings.Join(meta, mutedStyle.Render(" · ")) + "\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(renderMarkdown(workspace.HTMLToMarkdown(p.StatementHTML), width))
+	if len(p.Similar) > 0 {
+		b.WriteString("\n" + showTitleStyle.Render("Similar questions") + "\n")
+		for _, sp := range p.Similar {
+			b.WriteString(fmt.Sprintf("  %s %s\n", sp.Title, mutedStyle.Render("("+sp.Slug+", "+sp.Difficulty+")")))
+		}
+	}
+	if len(p.Hints) > 0 {
+		b.WriteString("\n" + mutedStyle.Render(fmt.Sprintf("%d hint(s) available: leet hint %s", len(p.Hints), p.Slug)) + "\n")
+	}
+	return b.String()
+}
+
+// renderMarkdown styles the Markdown produced by workspace.HTMLToMarkdown for
+// the terminal: code blocks get a side rule, paragraphs are wrapped to width
+// and inline code, bold and italics are highlighted.
+func renderMarkdown(md string, width int) string {
+	var b strings.Builder
+	var block []string
+	inBlock := false
+	for _, line := range strings.Split(strings.TrimRight(md, "\n"), "\n") {
+		if strings.HasPrefix(line, "```") {
+			if inBlock {
+				b.WriteString(showBlockStyle.Render(strings.Join(block, "\n")) + "\n")
+				block = block[:0]
+			}
+			inBlock = !inBlock
+			continue
+		}
+		if inBlock {
+			block = append(block, line)
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			b.WriteString(showTitleStyle.Render(strings.TrimLeft(line, "# ")) + "\n")
+			continue
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		b.WriteString(lipgloss.NewStyle().Width(width).PaddingLeft(indent).Render(styleInline(strings.TrimLeft(line, " "))) + "\n")
+	}
+	if inBlock && len(block) > 0 {
+		b.WriteString(showBlockStyle.Render(strings.Join(block, "\n")) + "\n")
+	}
+	return b.String()
+}
+
+func styleInline(line string) string {
+	return mdInlineRe.ReplaceAllStringFunc(line, func(m string) string {
+		switch {
+		case strings.HasPrefix(m, "`"):
+			return showCodeStyle.Render(strings.Trim(m, "`"))
+		case strings.HasPrefix(m, "**"):
+			return showTitleStyle.Render(styleInline(m[2 : len(m)-2]))
+		default:
+			return lipgloss.NewStyle().Italic(true).Render(styleInline(m[1 : len(m)-1]))
+		}
+	})
+}
+
+// showWidth is the terminal width capped at 100 columns, or 100 when stdout
+// is not a terminal.
+func showWidth() int {
+	w, _, err := term.GetSize(os.Stdout.Fd())
+	if err != nil || w <= 0 {
+		return 100
+	}
+	return min(w, 100)
+}
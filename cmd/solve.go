@@ -2,15 +2,17 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/rand"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 
+	"leetcli/internal/leetcode"
 	"leetcli/internal/store"
-	"leetcli/internal/workspace"
 )
 
 var solveSlug string
@@ -20,6 +22,9 @@ var solveTopic string
 var solveTimer int
 var solveNoTimer bool
 var solveCount int
+var solveLang string
+var solveStatus string
+var solvePlan bool
 
 var solveCmd = &cobra.Command{
 	Use:   "solve",
@@ -33,54 +38,99 @@ var solveCmd = &cobra.Command{
 		}
 		defer a.close()
 
+		l, err := a.lang(solveLang)
+		if err != nil {
+			return err
+		}
 		cli := a.client()
 		slugs := make([]string, 0)
+		var filter *store.CatalogFilter
 
 		chosenSlug := strings.TrimSpace(solveSlug)
-		if chosenSlug == "" && !solveRandom {
+		if chosenSlug == "" && !solveRandom && !solvePlan {
 			solveRandom = true
 		}
 		if chosenSlug != "" {
 			slugs = append(slugs, chosenSlug)
-		} else if solveCount > 1 {
-			all, err := cli.ListSummaries(ctx)
+		} else if solvePlan {
+			p, err := loadPlan(ctx, a, "")
 			if err != nil {
 				return err
 			}
-			rand.Shuffle(len(all), func(i, j int) { all[i], all[j] = all[j], all[i] })
-			for _, s := range all {
-				if s.PaidOnly {
-					continue
-				}
-				if solveDifficulty != "" && !strings.EqualFold(s.Difficulty, solveDifficulty) {
-					continue
-				}
-				slugs = append(slugs, s.Slug)
-				if len(slugs) >= solveCount {
-					break
-				}
+			statuses, err := a.store.ProblemStatuses(ctx)
+			if err != nil {
+				return err
+			}
+			// Every remaining problem is a candidate so premium-only or
+			// unavailable ones can be skipped.
+			for _, it := range planRemaining(p, statuses) {
+				slugs = append(slugs, it.Slug)
 			}
 			if len(slugs) == 0 {
-				return fmt.Errorf("no problems found for requested filters")
+				return fmt.Errorf("every problem in %s is solved", p.Name)
 			}
 		} else {
-			s, err := cli.PickRandom(ctx, solveDifficulty)
+			offline, err := ensureCatalog(ctx, a)
+			if err != nil {
+				return err
+			}
+			status, err := localStatus(solveStatus)
 			if err != nil {
 				return err
 			}
-			slugs = append(slugs, s.Slug)
+			filter = &store.CatalogFilter{Difficulty: solveDifficulty, Tag: leetcode.TagSlug(solveTopic), Status: status, CachedOnly: offline}
+			picks, err := a.store.RandomCatalog(ctx, *filter, solveCount)
+			if err != nil {
+				return err
+			}
+			if len(picks) == 0 {
+				return fmt.Errorf("no problems found for requested filters")
+			}
+			for _, p := range picks {
+				slugs = append(slugs, p.Slug)
+			}
 		}
 
 		prepared := 0
-		for _, slug := range slugs {
+		want := len(slugs)
+		if solvePlan {
+			want = solveCount
+		}
+		for i := 0; i < len(slugs) && prepared < want; i++ {
+			slug := slugs[i]
 			q, err := cli.Question(ctx, slug)
 			if err != nil {
-				continue
+				row, cErr := a.store.GetProblem(ctx, slug)
+				if cErr != nil || row.StatementHTML == "" {
+					if chosenSlug != "" {
+						return err
+					}
+					if solvePlan {
+						if !errors.Is(err, leetcode.ErrPremiumOnly) && !errors.Is(err, leetcode.ErrNotFound) {
+							return err
+						}
+						fmt.Printf("skip %s: %v\n", slug, err)
+						continue
+					}
+					// Likely offline: fall back to picks whose statements are cached.
+					if filter != nil && !filter.CachedOnly {
+						filter.CachedOnly = true
+						more, _ := a.store.RandomCatalog(ctx, *filter, solveCount)
+						for _, p := range more {
+							if !slices.Contains(slugs, p.Slug) {
+								slugs = append(slugs, p.Slug)
+							}
+						}
+					}
+					continue
+				}
+				q = questionFromProblem(row.Problem)
 			}
-			if solveTopic != "" {
+			// Random picks were already filtered against the catalog.
+			if chosenSlug != "" && solveTopic != "" {
 				matched := false
 				for _, t := range q.Topics {
-					if strings.EqualFold(t, solveTopic) {
+					if leetcode.TagSlug(t) == leetcode.TagSlug(solveTopic) {
 						matched = true
 						break
 					}
@@ -89,37 +139,11 @@ var solveCmd = &cobra.Command{
 					continue
 				}
 			}
-			if solveDifficulty != "" && !strings.EqualFold(q.Difficulty, solveDifficulty) {
+			if chosenSlug != "" && solveDifficulty != "" && !strings.EqualFold(q.Difficulty, solveDifficulty) {
 				continue
 			}
 
-			p := store.Problem{
-				FrontendID:    q.FrontendID,
-				QuestionID:    q.QuestionID,
-				Slug:          q.Slug,
-				Title:         q.Title,
-				Difficulty:    q.Difficulty,
-				Topics:        q.Topics,
-				StatementHTML: q.StatementHTML,
-				ExampleTests:  q.ExampleTests,
-				CodeStub:      q.PythonStub,
-				Status:        "in_progress",
-			}
-			if err := a.store.UpsertProblem(ctx, p); err != nil {
-				return err
-			}
-			row, err := a.store.GetProblem(ctx, q.Slug)
-			if err != nil {
-				return err
-			}
-			if row.Status == "todo" {
-				_ = a.store.SetProblemStatus(ctx, q.Slug, "in_progress")
-				row.Status = "in_progress"
-			}
-			if err := workspace.EnsureProblemFiles(a.cfg.Workspace.ProblemsDir, row); err != nil {
-				return err
-			}
-			if err := workspace.WriteMetaJSON(a.cfg.Workspace.ProblemsDir, row); err != nil {
+			if _, err := prepareProblem(ctx, a, q, l); err != nil {
 				return err
 			}
 			prepared++
@@ -145,12 +169,31 @@ var solveCmd = &cobra.Command{
 	},
 }
 
+// localStatus maps a --status value to the status stored for problems.
+func localStatus(v string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(v)) {
+	case "":
+		return "", nil
+	case "todo":
+		return "todo", nil
+	case "attempted", "in_progress":
+		return "in_progress", nil
+	case "solved":
+		return "solved", nil
+	default:
+		return "", fmt.Errorf("unknown status %q (use todo, attempted or solved)", v)
+	}
+}
+
 func init() {
 	solveCmd.Flags().StringVar(&solveSlug, "slug", "", "specific problem slug")
 	solveCmd.Flags().BoolVar(&solveRandom, "random", false, "pick a random problem")
 	solveCmd.Flags().StringVar(&solveDifficulty, "difficulty", "", "filter by difficulty (Easy/Medium/Hard)")
-	solveCmd.Flags().StringVar(&solveTopic, "topic", "", "best-effort topic filter")
+	solveCmd.Flags().StringVar(&solveTopic, "topic", "", "filter by topic name or tag slug (e.g. \"Dynamic Programming\")")
+	solveCmd.Flags().StringVar(&solveStatus, "status", "", "filter by your progress (todo/attempted/solved)")
 	solveCmd.Flags().IntVar(&solveCount, "count", 1, "number of problems to cache/prepare")
 	solveCmd.Flags().IntVar(&solveTimer, "timer", 30, "default solve timer in minutes")
 	solveCmd.Flags().BoolVar(&solveNoTimer, "no-timer", false, "do not auto-start timer")
+	solveCmd.Flags().BoolVar(&solvePlan, "plan", false, "prepare the next unsolved problem(s) of the active study plan")
+	solveCmd.Flags().StringVar(&solveLang, "lang", "", "solution language (defaults to config language)")
 }
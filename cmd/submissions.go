@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var submissionsAll bool
+
+var submissionsCmd = &cobra.Command{
+	Use:   "submissions [slug]",
+	Short: "List past submissions",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		a, err := loadApp(ctx)
+		if err != nil {
+			return err
+		}
+		defer a.close()
+
+		slug := ""
+		if !submissionsAll {
+			if len(args) == 1 {
+				slug = args[0]
+			}
+			slug, err = problemSlugFromArgOrCurrent(ctx, a, slug)
+			if err != nil {
+				return err
+			}
+		}
+		subs, err := a.store.ListSubmissions(ctx, slug)
+		if err != nil {
+			return err
+		}
+		if len(subs) == 0 {
+			fmt.Println("No submissions recorded")
+			return nil
+		}
+		fmt.Printf("%-12s %-19s %-24s %-11s %-22s %-9s %-9s\n", "ID", "WHEN", "PROBLEM", "LANG", "STATUS", "RUNTIME", "MEMORY")
+		for _, s := range subs {
+			status := s.Status
+			if s.Status == "Accepted" {
+				status = passStyle.Render(fmt.Sprintf("%-22s", s.Status))
+			} else {
+				status = failStyle.Render(fmt.Sprintf("%-22s", truncate(s.Status, 22)))
+			}
+			fmt.Printf("%-12d %-19s %-24s %-11s %s %-9s %-9s\n", s.ID, s.CreatedAt, truncate(s.Slug, 24), blankAsDash(s.Lang), status, blankAsDash(s.Runtime), blankAsDash(s.Memory))
+		}
+		return nil
+	},
+}
+
+var submissionsShowCmd = &cobra.Command{
+	Use:   "show <id>",
+	Short: "Show a stored submission's verdict and code",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		a, err := loadApp(ctx)
+		if err != nil {
+			return err
+		}
+		defer a.close()
+
+		id, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid submission id %q", args[0])
+		}
+		s, err := a.store.GetSubmission(ctx, id)
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("no stored submission %d", id)
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Submission %d for %s (%s) at %s\n", s.ID, s.Slug, blankAsDash(s.Lang), s.CreatedAt)
+		fmt.Printf("Status: %s\n", s.Status)
+		if s.TotalTestcases > 0 {
+			fmt.Printf("Passed %d/%d testcases\n", s.TotalCorrect, s.TotalTestcases)
+		}
+		if s.Runtime != "" || s.Memory != "" {
+			fmt.Printf("Runtime: %s  Memory: %s\n", s.Runtime, s.Memory)
+		}
+		if s.CompileError != "" {
+			fmt.Printf("\n%s\n", strings.TrimRight(s.CompileError, "\n"))
+		}
+		if s.LastTestcase != "" {
+			fmt.Printf("\nFailing input:\n  %s\n", indentTail(s.LastTestcase, "  "))
+			fmt.Printf("Expected: %s\nOutput:   %s\n", s.ExpectedOutput, s.CodeOutput)
+		}
+		if s.RuntimeError != "" {
+			fmt.Printf("\n%s\n", strings.TrimRight(s.RuntimeError, "\n"))
+		}
+		if s.Code == "" {
+			fmt.Println("\n(no code snapshot stored)")
+			return nil
+		}
+		fmt.Printf("\n%s\n", strings.TrimRight(s.Code, "\n"))
+		return nil
+	},
+}
+
+func blankAsDash(s string) string {
+	if strings.TrimSpace(s) == "" {
+		return "-"
+	}
+	return s
+}
+
+func init() {
+	submissionsCmd.Flags().BoolVar(&submissionsAll, "all", false, "list submissions for every problem")
+	submissionsCmd.AddCommand(submissionsShowCmd)
+}
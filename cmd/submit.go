@@ -2,18 +2,33 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+
+	"leetcli/internal/lang"
+	"leetcli/internal/leetcode"
+	"leetcli/internal/tester"
 )
 
+var submitLang string
+var submitAddFailing bool
+var submitResume int64
+
 var submitCmd = &cobra.Command{
 	Use:   "submit [slug]",
-	Short: "Submit solution.py to LeetCode (Python3)",
+	Short: "Submit the solution file to LeetCode",
 	Args:  cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ctx := context.Background()
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
 		a, err := loadApp(ctx)
 		if err != nil {
 			return err
@@ -24,38 +39,185 @@ var submitCmd = &cobra.Command{
 		if len(args) == 1 {
 			slug = args[0]
 		}
+		if submitResume != 0 {
+			return resumeSubmission(ctx, a, slug, submitResume)
+		}
 		slug, err = problemSlugFromArgOrCurrent(ctx, a, slug)
 		if err != nil {
 			return err
 		}
-		p, err := a.store.GetProblem(ctx, slug)
+		l, err := a.langFor(ctx, slug, submitLang)
 		if err != nil {
 			return err
 		}
-		if p.QuestionID == "" {
-			q, qErr := a.client().Question(ctx, slug)
-			if qErr != nil {
-				return qErr
-			}
-			p.QuestionID = q.QuestionID
+		return submitSolution(ctx, a, slug, l, submitAddFailing)
+	},
+}
+
+// submitSolution submits slug's working solution in l, waits for the
+// verdict and records it. leet submit and browse both go through it.
+func submitSolution(ctx context.Context, a *app, slug string, l lang.Language, addFailing bool) error {
+	if iv, ok := a.runningInterview(ctx); ok && iv.Has(slug) && time.Now().After(iv.Deadline()) {
+		return fmt.Errorf("time is up for interview #%d; run leet interview end for the report", iv.ID)
+	}
+	p, err := a.store.GetProblem(ctx, slug)
+	if err != nil {
+		return err
+	}
+	if p.QuestionID == "" {
+		q, qErr := a.client().Question(ctx, slug)
+		if qErr != nil {
+			return qErr
+		}
+		p.QuestionID = q.QuestionID
+	}
+
+	code, err := os.ReadFile(a.workingSolution(ctx, slug, l))
+	if err != nil {
+		return err
+	}
+	submitted := l.SubmitCode(string(code))
+	var res leetcode.SubmitResult
+	sent := time.Now()
+	err = withSpinner("Judging "+slug+"...", func() error {
+		var sErr error
+		res, sErr = a.client().Submit(ctx, slug, p.QuestionID, l.Slug, submitted)
+		return sErr
+	})
+	if errors.Is(err, context.Canceled) && res.SubmissionID != 0 {
+		res.Status = "Pending"
+		_ = a.store.SaveSubmission(context.Background(), submissionFromResult(slug, l, submitted, sent, res))
+		fmt.Printf("Interrupted; submission %d is still being judged. Resume with: leet submit --resume %d\n", res.SubmissionID, res.SubmissionID)
+		return fmt.Errorf("submit interrupted")
+	}
+	if err != nil {
+		return err
+	}
+	return finishSubmission(ctx, a, slug, l, submitted, sent, res, addFailing)
+}
+
+// resumeSubmission collects the verdict of a submission that was still
+// pending, reusing the language and code stored when it was sent.
+func resumeSubmission(ctx context.Context, a *app, slug string, id int64) error {
+	l, err := a.langFor(ctx, slug, submitLang)
+	if err != nil {
+		return err
+	}
+	code := ""
+	if stored, err := a.store.GetSubmission(ctx, id); err == nil {
+		slug = stored.Slug
+		code = stored.Code
+		if sl, err := lang.Lookup(stored.Lang); err == nil {
+			l = sl
 		}
+	} else if slug, err = problemSlugFromArgOrCurrent(ctx, a, slug); err != nil {
+		return err
+	}
+	var res leetcode.SubmitResult
+	err = withSpinner(fmt.Sprintf("Waiting for submission %d...", id), func() error {
+		var cErr error
+		res, cErr = a.client().CheckSubmission(ctx, slug, id)
+		return cErr
+	})
+	if err != nil {
+		return err
+	}
+	return finishSubmission(ctx, a, slug, l, code, time.Time{}, res, submitAddFailing)
+}
 
-		code, err := os.ReadFile(solutionPath(a.cfg.Workspace.ProblemsDir, slug))
-		if err != nil {
+// finishSubmission records and prints res. sent is when the submission went
+// to the judge, or zero when resuming one recorded earlier.
+func finishSubmission(ctx context.Context, a *app, slug string, l lang.Language, code string, sent time.Time, res leetcode.SubmitResult, addFailing bool) error {
+	if err := a.store.SaveSubmission(ctx, submissionFromResult(slug, l, code, sent, res)); err != nil {
+		return err
+	}
+	_ = syncMeta(ctx, a, slug, l)
+	printSubmitResult(res)
+	if res.Status == "Pending" {
+		fmt.Printf("Still judging. Resume with: leet submit --resume %d\n", res.SubmissionID)
+		return nil
+	}
+	printContestProgress(ctx, a, slug)
+	if res.Status == "Accepted" {
+		if err := finishOpenReview(ctx, a, slug, true, -1); err != nil {
 			return err
 		}
-		res, err := a.client().Submit(ctx, slug, p.QuestionID, string(code))
+	} else {
+		_ = a.store.AddReviewFailure(ctx, slug)
+	}
+	if addFailing && res.LastTestcase != "" {
+		added, err := tester.AddUserCase(filepath.Join(a.cfg.Workspace.ProblemsDir, slug), failingCase(res))
 		if err != nil {
 			return err
 		}
-		if err := a.store.SaveSubmissionResult(ctx, slug, res.Status, res.Runtime, res.Memory); err != nil {
-			return err
+		if added {
+			fmt.Println("Added the failing case to tests.json")
+		}
+	}
+	return nil
+}
+
+func printSubmitResult(res leetcode.SubmitResult) {
+	fmt.Printf("Submission %d: %s\n", res.SubmissionID, res.Status)
+	if res.TotalTestcases > 0 {
+		fmt.Printf("Passed %d/%d testcases\n", res.TotalCorrect, res.TotalTestcases)
+	}
+	if res.Runtime != "" || res.Memory != "" {
+		fmt.Printf("Runtime: %s  Memory: %s\n", res.Runtime, res.Memory)
+	}
+	if res.RuntimePercentile > 0 || res.MemoryPercentile > 0 {
+		fmt.Printf("Beats %.2f%% (runtime)  %.2f%% (memory)\n", res.RuntimePercentile, res.MemoryPercentile)
+	}
+	if res.CompileError != "" {
+		fmt.Printf("\n%s\n", strings.TrimRight(res.CompileError, "\n"))
+		return
+	}
+	if res.LastTestcase == "" {
+		return
+	}
+	fmt.Printf("\n%s failing case\n", failStyle.Render("✗"))
+	fmt.Printf("  Input:    %s\n", indentTail(res.LastTestcase, "            "))
+	if res.ExpectedOutput != "" || res.CodeOutput != "" {
+		want, got := highlightDiff(res.ExpectedOutput, res.CodeOutput)
+		fmt.Printf("  Expected: %s\n", want)
+		fmt.Printf("  Output:   %s\n", got)
+	}
+	if strings.TrimSpace(res.StdOutput) != "" {
+		fmt.Printf("  Stdout:\n    %s\n", indentTail(strings.TrimRight(res.StdOutput, "\n"), "    "))
+	}
+	if res.RuntimeError != "" {
+		fmt.Printf("  Error:\n    %s\n", indentTail(strings.TrimRight(res.RuntimeError, "\n"), "    "))
+	}
+}
+
+// failingCase turns the judge's last testcase into a tests.json entry with
+// one argument per input line.
+func failingCase(res leetcode.SubmitResult) tester.UserTestCase {
+	args := make([]any, 0)
+	for _, line := range strings.Split(res.LastTestcase, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
 		}
-		_ = syncMeta(ctx, a, slug)
-		fmt.Printf("Submission %d: %s\n", res.SubmissionID, res.Status)
-		if res.Runtime != "" || res.Memory != "" {
-			fmt.Printf("Runtime: %s  Memory: %s\n", res.Runtime, res.Memory)
+		var v any
+		if err := json.Unmarshal([]byte(line), &v); err != nil {
+			v = line
 		}
-		return nil
-	},
+		args = append(args, v)
+	}
+	c := tester.UserTestCase{Input: args}
+	if res.ExpectedOutput != "" {
+		var v any
+		if err := json.Unmarshal([]byte(res.ExpectedOutput), &v); err != nil {
+			v = res.ExpectedOutput
+		}
+		c.Expected = v
+	}
+	return c
+}
+
+func init() {
+	submitCmd.Flags().StringVar(&submitLang, "lang", "", "solution language (defaults to the language the problem was solved in)")
+	submitCmd.Flags().Int64Var(&submitResume, "resume", 0, "fetch the final verdict of a submission that was still pending")
+	submitCmd.Flags().BoolVar(&submitAddFailing, "add-failing", false, "append the failing testcase to tests.json as a regression test")
 }
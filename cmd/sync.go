@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"leetcli/internal/lang"
+	"leetcli/internal/leetcode"
+	"leetcli/internal/store"
+	"leetcli/internal/workspace"
+)
+
+var syncCode bool
+var syncLimit int
+
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Import solved/attempted problems and accepted submissions from your LeetCode account",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		a, err := loadApp(ctx)
+		if err != nil {
+			return err
+		}
+		defer a.close()
+
+		cli := a.client()
+		username, err := cli.ValidateAuth(ctx)
+		if err != nil {
+			return err
+		}
+		progress, err := cli.UserProgress(ctx)
+		if err != nil {
+			return err
+		}
+		solved, attempted := 0, 0
+		for _, s := range progress {
+			status := "in_progress"
+			if s.Status == "ac" {
+				status = "solved"
+				solved++
+			} else {
+				attempted++
+			}
+			p := store.Problem{Slug: s.Slug, FrontendID: s.FrontendID, Title: s.Title, Difficulty: s.Difficulty}
+			if err := a.store.MarkProgress(ctx, p, status); err != nil {
+				return err
+			}
+		}
+
+		recent, err := cli.RecentAccepted(ctx, username, syncLimit)
+		if err != nil {
+			return err
+		}
+		backfilled, downloaded := 0, 0
+		for _, r := range recent {
+			if err := a.store.MarkProgress(ctx, store.Problem{Slug: r.Slug, Title: r.Title}, "solved"); err != nil {
+				return err
+			}
+			added, err := a.store.AddActivityAt(ctx, r.Slug, "solved", "sync", r.Timestamp)
+			if err != nil {
+				return err
+			}
+			if added {
+				backfilled++
+			}
+			if !syncCode {
+				continue
+			}
+			ok, err := downloadAccepted(ctx, a, cli, r)
+			if err != nil {
+				fmt.Printf("Skipped code for %s: %v\n", r.Slug, err)
+				continue
+			}
+			if ok {
+				downloaded++
+			}
+		}
+
+		fmt.Printf("Synced %s: %d solved, %d attempted\n", username, solved, attempted)
+		fmt.Printf("Backfilled %d accepted submission(s) into activity\n", backfilled)
+		if syncCode {
+			fmt.Printf("Downloaded accepted code for %d problem(s)\n", downloaded)
+		}
+		return nil
+	},
+}
+
+// downloadAccepted fetches the problem and the accepted code for r and writes
+// the code as the workspace solution, leaving existing solution files alone.
+// The problem's language becomes the accepted code's, so later commands act
+// on the imported file.
+func downloadAccepted(ctx context.Context, a *app, cli *leetcode.Client, r leetcode.AcceptedSubmission) (bool, error) {
+	d, err := cli.SubmissionDetail(ctx, r.ID)
+	if err != nil {
+		return false, err
+	}
+	l, err := lang.Lookup(d.LangSlug)
+	if err != nil {
+		return false, err
+	}
+	q, err := cli.Question(ctx, r.Slug)
+	if err != nil {
+		return false, err
+	}
+	if err := a.store.UpsertProblem(ctx, problemFromQuestion(q)); err != nil {
+		return false, err
+	}
+	written, err := workspace.SeedSolution(a.cfg.Workspace.ProblemsDir, r.Slug, l, d.Code)
+	if err != nil {
+		return false, err
+	}
+	if err := a.store.SetProblemLang(ctx, r.Slug, l.Slug); err != nil {
+		return false, err
+	}
+	row, err := a.store.GetProblem(ctx, r.Slug)
+	if err != nil {
+		return false, err
+	}
+	if err := workspace.EnsureProblemFiles(a.cfg.Workspace.ProblemsDir, row, l); err != nil {
+		return false, err
+	}
+	if err := workspace.WriteMetaJSON(a.cfg.Workspace.ProblemsDir, row); err != nil {
+		return false, err
+	}
+	return written, nil
+}
+
+func init() {
+	syncCmd.Flags().BoolVar(&syncCode, "code", false, "download the last accepted code into each problem's workspace")
+	syncCmd.Flags().IntVar(&syncLimit, "limit", 20, "number of recent accepted submissions to import")
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"leetcli/internal/leetcode/fake"
+)
+
+func TestSyncCodeKeepsLanguage(t *testing.T) {
+	srv := startFake(t)
+	code := "func twoSum(nums []int, target int) []int {\n\treturn []int{0, 1}\n}"
+	srv.AddSubmission(fake.Submission{Slug: "two-sum", Lang: "golang", Code: code})
+
+	out, err := runLeet(t, "sync", "--code")
+	if err != nil {
+		t.Fatalf("sync --code: %v", err)
+	}
+	if !strings.Contains(out, "Downloaded accepted code for 1 problem(s)") {
+		t.Fatalf("sync output = %q, want one download", out)
+	}
+	dir := filepath.Join("problems", "two-sum")
+	b, err := os.ReadFile(filepath.Join(dir, "solution.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), "return []int{0, 1}") {
+		t.Errorf("solution.go = %q, want the accepted code", b)
+	}
+
+	if _, err := runLeet(t, "submit", "two-sum"); err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+	subs := srv.Submissions()
+	last := subs[len(subs)-1]
+	if last.Lang != "golang" || !strings.Contains(last.Code, "return []int{0, 1}") {
+		t.Errorf("submit sent %s code %q, want the imported Go solution", last.Lang, last.Code)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "solution.py")); err == nil {
+		t.Error("a config-language stub was created next to the imported code")
+	}
+}
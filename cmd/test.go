@@ -2,15 +2,28 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
+	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
 
+	"leetcli/internal/config"
+	"leetcli/internal/lang"
+	"leetcli/internal/leetcode"
+	"leetcli/internal/store"
 	"leetcli/internal/tester"
 	"leetcli/internal/workspace"
 )
 
+var testLang string
+var testLast bool
+var testRemote bool
+
 var testCmd = &cobra.Command{
 	Use:   "test [slug]",
 	Short: "Run local tests (examples + tests.json)",
@@ -31,25 +44,72 @@ var testCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		if testLast {
+			run, err := a.store.LastTestRun(ctx, slug)
+			if err != nil {
+				return fmt.Errorf("no stored test run for %s", slug)
+			}
+			fmt.Printf("Last test run for %s at %s\n", slug, run.CreatedAt)
+			printCaseTable(casesFromStore(run.Cases))
+			if len(run.Cases) == 0 && run.Output != "" {
+				fmt.Println(run.Output)
+			}
+			return nil
+		}
+
+		l, err := a.langFor(ctx, slug, testLang)
+		if err != nil {
+			return err
+		}
 		p, err := a.store.GetProblem(ctx, slug)
 		if err != nil {
 			return err
 		}
-		sPath := solutionPath(a.cfg.Workspace.ProblemsDir, slug)
+		sPath := a.workingSolution(ctx, slug, l)
 		cases, err := tester.LoadUserCases(filepath.Join(a.cfg.Workspace.ProblemsDir, slug))
 		if err != nil {
 			return err
 		}
-		res, err := tester.RunPython(sPath, p.ExampleTests, cases)
+		outputs := p.ExampleOutputs
+		if len(outputs) == 0 {
+			outputs = leetcode.ParseExampleOutputs(p.StatementHTML)
+		}
+		suite := tester.Suite{
+			ExampleTests:   p.ExampleTests,
+			ExampleOutputs: outputs,
+			UserCases:      cases,
+			Limits:         testLimits(a.cfg.Tester),
+		}
+		var res tester.Result
+		if testRemote {
+			if err := interviewBlocks(ctx, a, "test --remote"); err != nil {
+				return err
+			}
+			res, err = remoteTest(ctx, a, p, l, sPath, suite)
+		} else {
+			res, err = tester.Run(l, sPath, suite)
+		}
 		if err != nil {
 			return err
 		}
-		_ = a.store.SaveTestRun(ctx, slug, res.Passed, res.FailedCount, res.Output)
+		_ = a.store.SaveTestRun(ctx, slug, res.Passed, res.FailedCount, res.Output, casesToStore(res.Cases))
+		printCaseTable(res.Cases)
+		if res.Output != "" {
+			fmt.Println(res.Output)
+		}
 		if !res.Passed {
 			_ = workspace.AppendDebugLog(a.cfg.Workspace.ProblemsDir, slug, res.Output)
-			fmt.Printf("Tests failed for %s (failed=%d)\n", slug, res.FailedCount)
-			if res.Output != "" {
-				fmt.Println(res.Output)
+			if res.Status != tester.StatusHarnessError {
+				_ = a.store.AddReviewFailure(ctx, slug)
+			}
+			if res.Status == tester.StatusCompileError {
+				fmt.Printf("%s for %s\n", res.Status, slug)
+			} else {
+				notRun := ""
+				if res.NotRun > 0 {
+					notRun = fmt.Sprintf(", not run=%d", res.NotRun)
+				}
+				fmt.Printf("Tests failed for %s: %s (failed=%d%s)\n", slug, res.Status, res.FailedCount, notRun)
 			}
 			return fmt.Errorf("test failure")
 		}
@@ -57,3 +117,260 @@ var testCmd = &cobra.Command{
 		return nil
 	},
 }
+
+// remoteTest runs the suite's inputs on LeetCode's judge via Run Code and
+// maps the judge's answers onto the same result shape as a local run.
+func remoteTest(ctx context.Context, a *app, p store.ProblemRow, l lang.Language, sPath string, suite tester.Suite) (tester.Result, error) {
+	code, err := os.ReadFile(sPath)
+	if err != nil {
+		return tester.Result{}, err
+	}
+	dataInput := tester.DataInput(suite)
+	if strings.TrimSpace(dataInput) == "" {
+		return tester.Result{}, fmt.Errorf("no test input for %s: add cases to tests.json", p.Slug)
+	}
+	if p.QuestionID == "" {
+		q, err := a.client().Question(ctx, p.Slug)
+		if err != nil {
+			return tester.Result{}, err
+		}
+		p.QuestionID = q.QuestionID
+	}
+	var rr leetcode.RunResult
+	err = withSpinner("Running "+p.Slug+" on LeetCode...", func() error {
+		var rErr error
+		rr, rErr = a.client().RunCode(ctx, p.Slug, p.QuestionID, l.Slug, l.SubmitCode(string(code)), dataInput)
+		return rErr
+	})
+	if err != nil {
+		return tester.Result{}, err
+	}
+	if rr.Status == "Pending" {
+		return tester.Result{}, fmt.Errorf("remote run %s is still pending", rr.InterpretID)
+	}
+	if rr.CompileError != "" {
+		return tester.Result{FailedCount: 1, Status: tester.StatusCompileError, Output: rr.CompileError}, nil
+	}
+
+	res := tester.Result{Passed: true, Status: tester.StatusAccepted}
+	for i, rc := range rr.Cases {
+		c := tester.CaseResult{
+			Name:     fmt.Sprintf("remote %d", i+1),
+			Status:   tester.StatusAccepted,
+			Input:    rc.Input,
+			Expected: rc.Expected,
+			Actual:   rc.Actual,
+			Stdout:   rc.Stdout,
+			Passed:   rc.Passed,
+		}
+		if !c.Passed {
+			c.Status = tester.StatusWrongAnswer
+		}
+		res.Cases = append(res.Cases, c)
+	}
+	if rr.RuntimeError != "" {
+		c := tester.CaseResult{Name: fmt.Sprintf("remote %d", len(res.Cases)+1), Status: tester.StatusRuntimeError, Error: rr.RuntimeError}
+		res.Cases = append(res.Cases, c)
+	}
+	for _, c := range res.Cases {
+		if !c.Passed {
+			if res.Passed {
+				res.Status = c.Status
+			}
+			res.Passed = false
+			res.FailedCount++
+		}
+	}
+	if res.Passed && !rr.Correct && rr.Status != "" && rr.Status != "Accepted" {
+		// The judge rejected the run without saying which case failed.
+		res.Cases = append(res.Cases, tester.CaseResult{
+			Name:   fmt.Sprintf("remote %d", len(res.Cases)+1),
+			Status: rr.Status,
+			Input:  dataInput,
+			Error:  fmt.Sprintf("LeetCode judged the run %s without per-case results", rr.Status),
+		})
+		res.Passed = false
+		res.Status = rr.Status
+		res.FailedCount = 1
+	}
+	if rr.Runtime != "" || rr.Memory != "" {
+		res.Output = fmt.Sprintf("Remote run %s: %s (runtime %s, memory %s)", rr.InterpretID, rr.Status, rr.Runtime, rr.Memory)
+	}
+	return res, nil
+}
+
+var (
+	passStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#22C55E")).Bold(true)
+	failStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444")).Bold(true)
+	mutedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	wantStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#22C55E")).Underline(true)
+	gotStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444")).Underline(true)
+)
+
+func printCaseTable(cases []tester.CaseResult) {
+	if len(cases) == 0 {
+		return
+	}
+	fmt.Printf("%-3s %-16s %-6s %9s  %-28s %-28s\n", "#", "CASE", "RESULT", "TIME", "EXPECTED", "ACTUAL")
+	for i, c := range cases {
+		result := passStyle.Render("PASS")
+		if !c.Passed {
+			result = failStyle.Render(verdictLabel(c.Status))
+		} else if c.Expected == "" {
+			result = mutedStyle.Render("RAN ")
+		}
+		expected := c.Expected
+		if expected == "" {
+			expected = "-"
+		}
+		actual := c.Actual
+		if c.Status == tester.StatusNotRun {
+			actual = "not run"
+		} else if c.Error != "" {
+			actual = "error"
+		}
+		fmt.Printf("%-3d %-16s %s   %9s  %-28s %-28s\n", i+1, truncate(c.Name, 16), result, formatElapsed(c.Elapsed), truncate(expected, 28), truncate(actual, 28))
+	}
+	for _, c := range cases {
+		if c.Passed || c.Status == tester.StatusNotRun {
+			continue
+		}
+		fmt.Printf("\n%s %s\n", failStyle.Render("✗"), c.Name)
+		fmt.Printf("  Input:    %s\n", indentTail(c.Input, "            "))
+		if c.Error == "" && c.Expected != "" {
+			want, got := highlightDiff(c.Expected, c.Actual)
+			fmt.Printf("  Expected: %s\n", want)
+			fmt.Printf("  Actual:   %s\n", got)
+		}
+		if strings.TrimSpace(c.Stdout) != "" {
+			fmt.Printf("  Stdout:\n    %s\n", indentTail(strings.TrimRight(c.Stdout, "\n"), "    "))
+		}
+		if c.Error != "" {
+			fmt.Printf("  Error:\n    %s\n", indentTail(strings.TrimRight(c.Error, "\n"), "    "))
+		}
+	}
+	fmt.Println()
+}
+
+// highlightDiff marks where expected and actual diverge: element-wise for
+// JSON lists, and from the first differing character for anything else.
+func highlightDiff(expected, actual string) (string, string) {
+	var want, got []json.RawMessage
+	if json.Unmarshal([]byte(expected), &want) == nil && json.Unmarshal([]byte(actual), &got) == nil {
+		return renderListDiff(want, got, wantStyle), renderListDiff(got, want, gotStyle)
+	}
+	we, ge := []rune(expected), []rune(actual)
+	i := 0
+	for i < len(we) && i < len(ge) && we[i] == ge[i] {
+		i++
+	}
+	return string(we[:i]) + wantStyle.Render(string(we[i:])), string(ge[:i]) + gotStyle.Render(string(ge[i:]))
+}
+
+func renderListDiff(items, other []json.RawMessage, style lipgloss.Style) string {
+	parts := make([]string, len(items))
+	for i, it := range items {
+		s := compactJSON(it)
+		if i >= len(other) || s != compactJSON(other[i]) {
+			s = style.Render(s)
+		}
+		parts[i] = s
+	}
+	return "[" + strings.Join(parts, ",") + "]"
+}
+
+func compactJSON(raw json.RawMessage) string {
+	var v any
+	if json.Unmarshal(raw, &v) != nil {
+		return string(raw)
+	}
+	enc, _ := json.Marshal(v)
+	return string(enc)
+}
+
+func verdictLabel(status string) string {
+	switch status {
+	case tester.StatusTimeLimit:
+		return "TLE "
+	case tester.StatusMemoryLimit:
+		return "MLE "
+	case tester.StatusRuntimeError:
+		return "RE  "
+	case tester.StatusNotRun:
+		return "SKIP"
+	case tester.StatusHarnessError:
+		return "HARN"
+	default:
+		return "FAIL"
+	}
+}
+
+func casesToStore(cases []tester.CaseResult) []store.TestCase {
+	out := make([]store.TestCase, 0, len(cases))
+	for _, c := range cases {
+		out = append(out, store.TestCase{
+			Name:      c.Name,
+			Status:    c.Status,
+			Input:     c.Input,
+			Expected:  c.Expected,
+			Actual:    c.Actual,
+			Stdout:    c.Stdout,
+			ElapsedMS: float64(c.Elapsed) / float64(time.Millisecond),
+			Error:     c.Error,
+			Passed:    c.Passed,
+		})
+	}
+	return out
+}
+
+func casesFromStore(cases []store.TestCase) []tester.CaseResult {
+	out := make([]tester.CaseResult, 0, len(cases))
+	for _, c := range cases {
+		out = append(out, tester.CaseResult{
+			Name:     c.Name,
+			Status:   c.Status,
+			Input:    c.Input,
+			Expected: c.Expected,
+			Actual:   c.Actual,
+			Stdout:   c.Stdout,
+			Elapsed:  time.Duration(c.ElapsedMS * float64(time.Millisecond)),
+			Error:    c.Error,
+			Passed:   c.Passed,
+		})
+	}
+	return out
+}
+
+func testLimits(c config.TesterConfig) tester.Limits {
+	return tester.Limits{
+		CaseTimeout: time.Duration(c.CaseTimeoutSec * float64(time.Second)),
+		RunTimeout:  time.Duration(c.RunTimeoutSec * float64(time.Second)),
+		MemoryMB:    c.MemoryLimitMB,
+	}
+}
+
+func formatElapsed(d time.Duration) string {
+	if d < time.Millisecond {
+		return fmt.Sprintf("%dµs", d.Microseconds())
+	}
+	return fmt.Sprintf("%.1fms", float64(d)/float64(time.Millisecond))
+}
+
+func truncate(s string, n int) string {
+	s = strings.ReplaceAll(s, "\n", " ")
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
+
+func indentTail(s, indent string) string {
+	return strings.ReplaceAll(s, "\n", "\n"+indent)
+}
+
+func init() {
+	testCmd.Flags().StringVar(&testLang, "lang", "", "solution language (defaults to the language the problem was solved in)")
+	testCmd.Flags().BoolVar(&testRemote, "remote", false, "run the examples and tests.json cases on LeetCode's judge instead of locally")
+	testCmd.Flags().BoolVar(&testLast, "last", false, "show the stored results of the last test run instead of running tests")
+}
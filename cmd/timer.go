@@ -67,7 +67,7 @@ var timerStopCmd = &cobra.Command{
 			fmt.Printf("No active timer for %s\n", slug)
 			return nil
 		}
-		_ = syncMeta(ctx, a, slug)
+		_ = syncMeta(ctx, a, slug, a.problemLang(ctx, slug))
 		fmt.Printf("Stopped timer for %s: +%dm%ds\n", slug, dur/60, dur%60)
 		return nil
 	},
@@ -96,7 +96,7 @@ var timerExtendCmd = &cobra.Command{
 		if err := a.store.AddManualTime(ctx, slug, timerExtendMinutes); err != nil {
 			return err
 		}
-		_ = syncMeta(ctx, a, slug)
+		_ = syncMeta(ctx, a, slug, a.problemLang(ctx, slug))
 		fmt.Printf("Added %d minutes to %s\n", timerExtendMinutes, slug)
 		return nil
 	},
@@ -111,4 +111,5 @@ func init() {
 	timerCmd.AddCommand(timerStartCmd)
 	timerCmd.AddCommand(timerStopCmd)
 	timerCmd.AddCommand(timerExtendCmd)
+	timerCmd.AddCommand(timerWatchCmd)
 }
@@ -0,0 +1,292 @@
+package cmd
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+
+	"leetcli/internal/store"
+)
+
+var timerWatchCmd = &cobra.Command{
+	Use:   "watch [slug]",
+	Short: "Show a full-screen countdown for the running timer",
+	Long: `Show a full-screen countdown with a progress bar for the running timer.
+
+A warning is sent at every threshold in timer.warn_at (a share of the target
+elapsed such as "50%", or a time left such as "5m") and again when the target
+runs out. Warnings go through notify-send when it is installed and
+timer.notify is on; otherwise the terminal bell rings.
+
+A contest or mock interview problem without a timer of its own shows the
+contest or interview clock.
+
+Press q to leave the timer running, or s to stop it. The watch closes by
+itself when the timer is stopped or restarted elsewhere.`,
+	Example: `  leet timer start --minutes 45
+  leet timer watch`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		a, err := loadApp(ctx)
+		if err != nil {
+			return err
+		}
+		defer a.close()
+
+		slug := ""
+		if len(args) == 1 {
+			slug = args[0]
+		}
+		slug, err = problemSlugFromArgOrCurrent(ctx, a, slug)
+		if err != nil {
+			return err
+		}
+		t, err := a.store.ActiveTimer(ctx, slug)
+		if errors.Is(err, sql.ErrNoRows) {
+			if run, ok := a.runningContest(ctx); ok && run.Has(slug) {
+				t, err = a.store.ActiveTimer(ctx, run.ContestSlug)
+			} else if iv, ok := a.runningInterview(ctx); ok && iv.Has(slug) {
+				t, err = a.store.ActiveTimer(ctx, iv.TimerSlug())
+			}
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("no active timer for %s; start one with leet timer start %s", slug, slug)
+		}
+		if err != nil {
+			return fmt.Errorf("find active timer: %w", err)
+		}
+		if t.TargetMinutes <= 0 {
+			return fmt.Errorf("the timer for %s has no target to count down to", slug)
+		}
+		warnings, err := parseTimerWarnings(a.cfg.Timer.WarnAt, time.Duration(t.TargetMinutes)*time.Minute)
+		if err != nil {
+			return err
+		}
+
+		m := newTimerModel(ctx, a, t, warnings)
+		final, err := tea.NewProgram(m, tea.WithAltScreen()).Run()
+		if err != nil {
+			return err
+		}
+		fm := final.(timerModel)
+		if fm.err != nil {
+			return fm.err
+		}
+		if fm.gone {
+			fmt.Printf("The timer for %s was stopped or replaced elsewhere.\n", t.Slug)
+			return nil
+		}
+		if !fm.stopped {
+			state := formatCountdown(time.Until(t.Deadline())) + " left"
+			if fm.exceeded {
+				state = formatCountdown(time.Since(t.Deadline())) + " over target"
+			}
+			fmt.Printf("Timer for %s is still running (%s). Stop it with leet timer stop.\n", slug, state)
+			return nil
+		}
+		_ = syncMeta(ctx, a, slug, a.problemLang(ctx, slug))
+		fmt.Printf("Stopped timer for %s: +%dm%ds", slug, fm.spent/60, fm.spent%60)
+		if over := fm.spent - t.TargetMinutes*60; over > 0 {
+			fmt.Print(failStyle.Render(fmt.Sprintf(" (%dm%ds over the %d-minute target)", over/60, over%60, t.TargetMinutes)))
+		}
+		fmt.Println()
+		return nil
+	},
+}
+
+// timerWarning fires once the countdown reaches at elapsed.
+type timerWarning struct {
+	at    time.Duration
+	label string
+}
+
+// parseTimerWarnings turns timer.warn_at entries into points on a countdown
+// of length target. Percentages are of the target elapsed; durations are the
+// time left.
+func parseTimerWarnings(specs []string, target time.Duration) ([]timerWarning, error) {
+	out := make([]timerWarning, 0, len(specs))
+	for _, spec := range specs {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+		if pct, ok := strings.CutSuffix(spec, "%"); ok {
+			n, err := strconv.ParseFloat(pct, 64)
+			if err != nil || n <= 0 || n >= 100 {
+				return nil, fmt.Errorf("invalid timer.warn_at entry %q: percentages must be between 0%% and 100%%", spec)
+			}
+			out = append(out, timerWarning{
+				at:    time.Duration(float64(target) * n / 100),
+				label: fmt.Sprintf("%s of your time used", spec),
+			})
+			continue
+		}
+		left, err := time.ParseDuration(spec)
+		if err != nil || left <= 0 {
+			return nil, fmt.Errorf("invalid timer.warn_at entry %q: use a percentage like 50%% or a duration like 5m", spec)
+		}
+		if left >= target {
+			continue
+		}
+		out = append(out, timerWarning{at: target - left, label: fmt.Sprintf("%s left", left)})
+	}
+	return out, nil
+}
+
+// timerTickMsg carries the time of a tick and whether the watched timer was
+// still the running one.
+type timerTickMsg struct {
+	at   time.Time
+	gone bool
+}
+
+type timerModel struct {
+	ctx      context.Context
+	a        *app
+	timer    store.TimerSession
+	warnings []timerWarning
+	// fired marks warnings already sent, including those passed before the
+	// watch started.
+	fired    []bool
+	exceeded bool
+	now      time.Time
+	width    int
+	msg      string
+	stopped  bool
+	spent    int
+	// gone is set when the timer was stopped or replaced by another command.
+	gone bool
+	err  error
+}
+
+func newTimerModel(ctx context.Context, a *app, t store.TimerSession, warnings []timerWarning) timerModel {
+	now := time.Now()
+	m := timerModel{ctx: ctx, a: a, timer: t, warnings: warnings, fired: make([]bool, len(warnings)), exceeded: t.Exceeded, now: now, width: 60}
+	elapsed := now.Sub(t.StartedAt)
+	for i, w := range warnings {
+		m.fired[i] = elapsed >= w.at
+	}
+	return m
+}
+
+// tick waits a second and reports whether the watched timer is still running.
+func (m timerModel) tick() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+		cur, err := m.a.store.ActiveTimer(m.ctx, m.timer.Slug)
+		gone := errors.Is(err, sql.ErrNoRows) || err == nil && cur.ID != m.timer.ID
+		return timerTickMsg{at: t, gone: gone}
+	})
+}
+
+func (m timerModel) Init() tea.Cmd {
+	return tea.Batch(m.tick(), func() tea.Msg { return timerTickMsg{at: time.Now()} })
+}
+
+func (m timerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch t := msg.(type) {
+	case tea.KeyMsg:
+		switch t.String() {
+		case "ctrl+c", "q", "esc":
+			return m, tea.Quit
+		case "s":
+			spent, err := m.a.store.StopTimer(m.ctx, m.timer.Slug)
+			m.stopped, m.spent, m.err = true, spent, err
+			return m, tea.Quit
+		}
+	case tea.WindowSizeMsg:
+		m.width = min(max(t.Width-4, 10), 80)
+	case timerTickMsg:
+		if t.gone {
+			m.gone = true
+			return m, tea.Quit
+		}
+		m.now = t.at
+		return m, tea.Batch(append(m.checkWarnings(), m.tick())...)
+	}
+	return m, nil
+}
+
+// checkWarnings records the warnings the countdown has reached and an
+// exceeded target, and returns the commands that send their notifications.
+func (m *timerModel) checkWarnings() []tea.Cmd {
+	var cmds []tea.Cmd
+	elapsed := m.now.Sub(m.timer.StartedAt)
+	for i, w := range m.warnings {
+		if m.fired[i] || elapsed < w.at {
+			continue
+		}
+		m.fired[i] = true
+		m.msg = fmt.Sprintf("%s: %s", m.now.Format("15:04"), w.label)
+		cmds = append(cmds, notifyCmd(m.a.cfg.Timer.Notify, "LeetCLI: "+m.timer.Slug, w.label))
+	}
+	if !m.exceeded && !m.now.Before(m.timer.Deadline()) {
+		m.exceeded = true
+		m.msg = fmt.Sprintf("%s: time is up", m.now.Format("15:04"))
+		_ = m.a.store.MarkTimerExceeded(m.ctx, m.timer.ID)
+		cmds = append(cmds, notifyCmd(m.a.cfg.Timer.Notify, "LeetCLI: "+m.timer.Slug, fmt.Sprintf("Time is up: the %d-minute target has run out", m.timer.TargetMinutes)))
+	}
+	return cmds
+}
+
+func (m timerModel) View() string {
+	target := time.Duration(m.timer.TargetMinutes) * time.Minute
+	elapsed := m.now.Sub(m.timer.StartedAt)
+	left := target - elapsed
+
+	var b strings.Builder
+	b.WriteString(showTitleStyle.Render("LeetCLI Timer") + "  " + m.timer.Slug + "\n")
+	b.WriteString(mutedStyle.Render(fmt.Sprintf("%d-minute target, started %s", m.timer.TargetMinutes, m.timer.StartedAt.Format("15:04"))) + "\n\n")
+	if left > 0 {
+		b.WriteString(showTitleStyle.Render(formatCountdown(left)) + " left\n\n")
+	} else {
+		b.WriteString(failStyle.Render("+"+formatCountdown(-left)) + " over target\n\n")
+	}
+	b.WriteString(progressBar(int(min(elapsed, target).Seconds()), int(target.Seconds()), m.width) + "\n\n")
+	if m.msg != "" {
+		b.WriteString(m.msg + "\n\n")
+	}
+	b.WriteString(mutedStyle.Render("q quit (timer keeps running)  s stop timer"))
+	return b.String()
+}
+
+// formatCountdown renders d as mm:ss, or h:mm:ss past an hour.
+func formatCountdown(d time.Duration) string {
+	d = max(d, 0).Round(time.Second)
+	h, m, s := int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, m, s)
+	}
+	return fmt.Sprintf("%02d:%02d", m, s)
+}
+
+// notifyCmd runs notify off the update loop so a slow notify-send does not
+// stall the countdown.
+func notifyCmd(desktop bool, title, body string) tea.Cmd {
+	return func() tea.Msg {
+		notify(desktop, title, body)
+		return nil
+	}
+}
+
+// notify sends a desktop notification through notify-send when desktop is
+// set and it is installed, and rings the terminal bell otherwise.
+func notify(desktop bool, title, body string) {
+	if desktop {
+		if path, err := exec.LookPath("notify-send"); err == nil {
+			if exec.Command(path, "--app-name=leetcli", title, body).Run() == nil {
+				return
+			}
+		}
+	}
+	fmt.Fprint(os.Stderr, "\a")
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"leetcli/internal/config"
+	"leetcli/internal/store"
+)
+
+func TestTimerWatchFollowsSession(t *testing.T) {
+	ctx := context.Background()
+	st, err := store.Open(filepath.Join(t.TempDir(), "leetcli.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Close()
+	a := &app{cfg: config.Default(), store: st}
+
+	if err := st.StartTimer(ctx, "two-sum", 1, false); err != nil {
+		t.Fatal(err)
+	}
+	tm, err := st.ActiveTimer(ctx, "two-sum")
+	if err != nil {
+		t.Fatal(err)
+	}
+	warnings, err := parseTimerWarnings([]string{"50%"}, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := newTimerModel(ctx, a, tm, warnings)
+
+	tick := func(m timerModel) (timerModel, tea.Cmd) {
+		t.Helper()
+		msg, ok := m.tick()().(timerTickMsg)
+		if !ok {
+			t.Fatal("tick did not produce a timerTickMsg")
+		}
+		// Move the clock past the target without waiting for it.
+		msg.at = tm.StartedAt.Add(2 * time.Minute)
+		next, cmd := m.Update(msg)
+		return next.(timerModel), cmd
+	}
+
+	m, _ = tick(m)
+	if m.gone || !m.exceeded || !m.fired[0] {
+		t.Fatalf("after the target: gone %v exceeded %v fired %v, want a running, exceeded timer", m.gone, m.exceeded, m.fired)
+	}
+
+	// A restart elsewhere replaces the watched session.
+	if _, err := st.StopTimer(ctx, "two-sum"); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.StartTimer(ctx, "two-sum", 30, false); err != nil {
+		t.Fatal(err)
+	}
+	m, cmd := tick(m)
+	if !m.gone {
+		t.Fatal("watch kept following a replaced timer")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("watch did not quit when its timer was replaced")
+	}
+	if cur, _ := st.ActiveTimer(ctx, "two-sum"); cur.Exceeded {
+		t.Error("the replacing timer was marked exceeded")
+	}
+}
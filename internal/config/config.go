@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/spf13/viper"
 )
@@ -18,15 +20,47 @@ type WorkspaceConfig struct {
 	DBPath      string `mapstructure:"db_path"`
 }
 
+type TesterConfig struct {
+	CaseTimeoutSec float64 `mapstructure:"case_timeout_sec"`
+	RunTimeoutSec  float64 `mapstructure:"run_timeout_sec"`
+	MemoryLimitMB  int     `mapstructure:"memory_limit_mb"`
+}
+
+type SubmitConfig struct {
+	PollTimeoutSec float64 `mapstructure:"poll_timeout_sec"`
+}
+
+type CatalogConfig struct {
+	TTLHours int `mapstructure:"ttl_hours"`
+}
+
+// TimerConfig controls leet timer watch. WarnAt entries are either a share
+// of the target elapsed ("50%") or a time left ("5m").
+type TimerConfig struct {
+	WarnAt []string `mapstructure:"warn_at"`
+	// Notify sends desktop notifications through notify-send when it is
+	// installed; otherwise, or when disabled, the terminal bell rings.
+	Notify bool `mapstructure:"notify"`
+}
+
 type Config struct {
-	Site      string          `mapstructure:"site"`
+	Site string `mapstructure:"site"`
+	// Translate prefers translated problem titles and statements on sites
+	// that serve them (leetcode.cn).
+	Translate bool            `mapstructure:"translate"`
+	Language  string          `mapstructure:"language"`
 	Auth      AuthConfig      `mapstructure:"auth"`
 	Workspace WorkspaceConfig `mapstructure:"workspace"`
+	Tester    TesterConfig    `mapstructure:"tester"`
+	Catalog   CatalogConfig   `mapstructure:"catalog"`
+	Submit    SubmitConfig    `mapstructure:"submit"`
+	Timer     TimerConfig     `mapstructure:"timer"`
 }
 
 type Paths struct {
 	XDGConfigFile   string
 	LocalConfigFile string
+	PlansDir        string
 }
 
 type Loaded struct {
@@ -34,14 +68,29 @@ type Loaded struct {
 	Paths  Paths
 }
 
-func defaultConfig() Config {
+func Default() Config {
 	return Config{
-		Site: "https://leetcode.com",
-		Auth: AuthConfig{},
+		Site:     "https://leetcode.com",
+		Language: "python3",
+		Auth:     AuthConfig{},
 		Workspace: WorkspaceConfig{
 			ProblemsDir: "problems",
 			DBPath:      filepath.Join(".leetcli", "leetcli.db"),
 		},
+		Tester: TesterConfig{
+			CaseTimeoutSec: 5,
+			RunTimeoutSec:  60,
+		},
+		Catalog: CatalogConfig{
+			TTLHours: 168,
+		},
+		Submit: SubmitConfig{
+			PollTimeoutSec: 120,
+		},
+		Timer: TimerConfig{
+			WarnAt: []string{"50%", "5m"},
+			Notify: true,
+		},
 	}
 }
 
@@ -58,6 +107,7 @@ func ResolvePaths() (Paths, error) {
 	return Paths{
 		XDGConfigFile:   filepath.Join(xdgHome, "leetcli", "config.yaml"),
 		LocalConfigFile: filepath.Join(".leetcli", "config.yaml"),
+		PlansDir:        filepath.Join(".leetcli", "plans"),
 	}, nil
 }
 
@@ -68,10 +118,19 @@ func Load() (Loaded, error) {
 	}
 
 	v := viper.New()
-	cfg := defaultConfig()
+	cfg := Default()
 	v.SetDefault("site", cfg.Site)
+	v.SetDefault("translate", cfg.Translate)
+	v.SetDefault("language", cfg.Language)
 	v.SetDefault("workspace.problems_dir", cfg.Workspace.ProblemsDir)
 	v.SetDefault("workspace.db_path", cfg.Workspace.DBPath)
+	v.SetDefault("tester.case_timeout_sec", cfg.Tester.CaseTimeoutSec)
+	v.SetDefault("tester.run_timeout_sec", cfg.Tester.RunTimeoutSec)
+	v.SetDefault("tester.memory_limit_mb", cfg.Tester.MemoryLimitMB)
+	v.SetDefault("catalog.ttl_hours", cfg.Catalog.TTLHours)
+	v.SetDefault("submit.poll_timeout_sec", cfg.Submit.PollTimeoutSec)
+	v.SetDefault("timer.warn_at", cfg.Timer.WarnAt)
+	v.SetDefault("timer.notify", cfg.Timer.Notify)
 
 	if _, err := os.Stat(paths.XDGConfigFile); err == nil {
 		v.SetConfigFile(paths.XDGConfigFile)
@@ -91,6 +150,8 @@ func Load() (Loaded, error) {
 	_ = v.BindEnv("auth.csrftoken", "CSRFTOKEN")
 	_ = v.BindEnv("auth.csrftoken", "LEETCODE_CSRFTOKEN")
 	_ = v.BindEnv("site", "LEETCODE_SITE")
+	_ = v.BindEnv("translate", "LEETCLI_TRANSLATE")
+	_ = v.BindEnv("language", "LEETCLI_LANG")
 	v.AutomaticEnv()
 
 	var out Config
@@ -116,16 +177,39 @@ func Save(cfg Config, toLocal bool) (string, error) {
 	}
 
 	content := fmt.Sprintf(`site: %q
+translate: %t
+language: %q
 auth:
   leetcode_session: %q
   csrftoken: %q
 workspace:
   problems_dir: %q
   db_path: %q
-`, cfg.Site, cfg.Auth.LeetCodeSession, cfg.Auth.CSRFToken, cfg.Workspace.ProblemsDir, cfg.Workspace.DBPath)
+tester:
+  case_timeout_sec: %g
+  run_timeout_sec: %g
+  memory_limit_mb: %d
+catalog:
+  ttl_hours: %d
+submit:
+  poll_timeout_sec: %g
+timer:
+  warn_at: [%s]
+  notify: %t
+`, cfg.Site, cfg.Translate, cfg.Language, cfg.Auth.LeetCodeSession, cfg.Auth.CSRFToken, cfg.Workspace.ProblemsDir, cfg.Workspace.DBPath,
+		cfg.Tester.CaseTimeoutSec, cfg.Tester.RunTimeoutSec, cfg.Tester.MemoryLimitMB, cfg.Catalog.TTLHours, cfg.Submit.PollTimeoutSec,
+		quoteList(cfg.Timer.WarnAt), cfg.Timer.Notify)
 
 	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
 		return "", fmt.Errorf("write config: %w", err)
 	}
 	return path, nil
 }
+
+func quoteList(vals []string) string {
+	quoted := make([]string, 0, len(vals))
+	for _, v := range vals {
+		quoted = append(quoted, strconv.Quote(v))
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,82 @@
+// Package contest scores virtual contest runs the way LeetCode ranks live
+// contests: points first, then finish time with penalties.
+package contest
+
+import (
+	"fmt"
+	"time"
+)
+
+// WrongPenalty is added to the finish time for each rejected submission on
+// a problem that was eventually accepted.
+const WrongPenalty = 5 * time.Minute
+
+// DefaultDuration is the length of weekly and biweekly contests.
+const DefaultDuration = 90 * time.Minute
+
+// Submission is one judged submission, At being its time since the start.
+type Submission struct {
+	At     time.Duration
+	Status string
+}
+
+// ProblemResult is a contest problem and how it went.
+type ProblemResult struct {
+	Slug     string
+	Title    string
+	Credit   int
+	Solved   bool
+	SolvedAt time.Duration
+	// Wrong counts penalised submissions before the first accepted one.
+	Wrong int
+}
+
+// Standing is the score of a run so far.
+type Standing struct {
+	Score     int
+	MaxScore  int
+	Finish    time.Duration
+	Penalties int
+	Problems  []ProblemResult
+}
+
+// Judge folds a problem's submissions, oldest first, into its result.
+// Submissions after the contest window, pending ones and compile errors are
+// ignored, as on LeetCode.
+func Judge(p ProblemResult, subs []Submission, window time.Duration) ProblemResult {
+	for _, s := range subs {
+		if s.At > window || s.Status == "Pending" || s.Status == "Compile Error" {
+			continue
+		}
+		if s.Status == "Accepted" {
+			p.Solved = true
+			p.SolvedAt = s.At
+			return p
+		}
+		p.Wrong++
+	}
+	return p
+}
+
+// Tally totals the results. The finish time is the last accepted
+// submission plus WrongPenalty per wrong submission on solved problems.
+func Tally(problems []ProblemResult) Standing {
+	st := Standing{Problems: problems}
+	for _, p := range problems {
+		st.MaxScore += p.Credit
+		if !p.Solved {
+			continue
+		}
+		st.Score += p.Credit
+		st.Penalties += p.Wrong
+		st.Finish = max(st.Finish, p.SolvedAt)
+	}
+	st.Finish += time.Duration(st.Penalties) * WrongPenalty
+	return st
+}
+
+// Clock formats d as h:mm:ss, the way contest finish times are shown.
+func Clock(d time.Duration) string {
+	d = d.Round(time.Second)
+	return fmt.Sprintf("%d:%02d:%02d", int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60)
+}
@@ -0,0 +1,79 @@
+package contest
+
+import (
+	"testing"
+	"time"
+)
+
+func TestJudge(t *testing.T) {
+	window := DefaultDuration
+	tests := []struct {
+		name      string
+		subs      []Submission
+		wantSolve bool
+		wantAt    time.Duration
+		wantWrong int
+	}{
+		{"no submissions", nil, false, 0, 0},
+		{"first try", []Submission{{At: 12 * time.Minute, Status: "Accepted"}}, true, 12 * time.Minute, 0},
+		{"wrong then accepted", []Submission{
+			{At: 5 * time.Minute, Status: "Wrong Answer"},
+			{At: 9 * time.Minute, Status: "Time Limit Exceeded"},
+			{At: 20 * time.Minute, Status: "Accepted"},
+			{At: 25 * time.Minute, Status: "Wrong Answer"},
+		}, true, 20 * time.Minute, 2},
+		{"compile errors and pending are free", []Submission{
+			{At: 5 * time.Minute, Status: "Compile Error"},
+			{At: 6 * time.Minute, Status: "Pending"},
+			{At: 7 * time.Minute, Status: "Accepted"},
+		}, true, 7 * time.Minute, 0},
+		{"late accepted ignored", []Submission{
+			{At: 80 * time.Minute, Status: "Wrong Answer"},
+			{At: 91 * time.Minute, Status: "Accepted"},
+		}, false, 0, 1},
+		{"accepted on the buzzer", []Submission{{At: window, Status: "Accepted"}}, true, window, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Judge(ProblemResult{Slug: "q", Credit: 4}, tt.subs, window)
+			if got.Solved != tt.wantSolve || got.SolvedAt != tt.wantAt || got.Wrong != tt.wantWrong {
+				t.Errorf("Judge = solved %v at %s with %d wrong, want %v at %s with %d",
+					got.Solved, got.SolvedAt, got.Wrong, tt.wantSolve, tt.wantAt, tt.wantWrong)
+			}
+		})
+	}
+}
+
+func TestTally(t *testing.T) {
+	tests := []struct {
+		name     string
+		problems []ProblemResult
+		want     Standing
+	}{
+		{"nothing solved", []ProblemResult{{Credit: 3, Wrong: 2}, {Credit: 4}}, Standing{MaxScore: 7}},
+		{"penalties only on solved problems", []ProblemResult{
+			{Credit: 3, Solved: true, SolvedAt: 10 * time.Minute, Wrong: 1},
+			{Credit: 4, Solved: true, SolvedAt: 30 * time.Minute},
+			{Credit: 5, Wrong: 3},
+		}, Standing{Score: 7, MaxScore: 12, Finish: 35 * time.Minute, Penalties: 1}},
+		{"finish is the last accepted", []ProblemResult{
+			{Credit: 4, Solved: true, SolvedAt: 50 * time.Minute, Wrong: 2},
+			{Credit: 3, Solved: true, SolvedAt: 15 * time.Minute},
+		}, Standing{Score: 7, MaxScore: 7, Finish: 60 * time.Minute, Penalties: 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Tally(tt.problems)
+			if got.Score != tt.want.Score || got.MaxScore != tt.want.MaxScore || got.Finish != tt.want.Finish || got.Penalties != tt.want.Penalties {
+				t.Errorf("Tally = score %d/%d finish %s penalties %d, want %d/%d finish %s penalties %d",
+					got.Score, got.MaxScore, got.Finish, got.Penalties, tt.want.Score, tt.want.MaxScore, tt.want.Finish, tt.want.Penalties)
+			}
+		})
+	}
+}
+
+func TestClock(t *testing.T) {
+	if got := Clock(time.Hour + 2*time.Minute + 3400*time.Millisecond); got != "1:02:03" {
+		t.Errorf("Clock = %q, want 1:02:03", got)
+	}
+}
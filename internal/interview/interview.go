@@ -0,0 +1,148 @@
+// Package interview scores mock interview sessions and renders their
+// reports.
+package interview
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ProblemResult is what happened to one interview problem.
+type ProblemResult struct {
+	Slug       string
+	Title      string
+	Difficulty string
+	Solved     bool
+	// SolvedAfter is the time from the start of the interview to the first
+	// accepted submission.
+	SolvedAfter time.Duration
+	// Rejected counts submissions judged before the first accepted one (or
+	// all of them when unsolved).
+	Rejected       int
+	TestRuns       int
+	LastTestPassed bool
+	Runtime        string
+	Memory         string
+}
+
+// Submission is one judged submission, At being its time since the start.
+type Submission struct {
+	At      time.Duration
+	Status  string
+	Runtime string
+	Memory  string
+}
+
+// Judge folds a problem's submissions, oldest first, into its result.
+// Submissions after the session, such as late ones from browse or
+// submit --resume, and pending ones are ignored.
+func Judge(p ProblemResult, subs []Submission, session time.Duration) ProblemResult {
+	for _, s := range subs {
+		if s.At > session || s.Status == "Pending" {
+			continue
+		}
+		if s.Status == "Accepted" {
+			p.Solved = true
+			p.SolvedAfter = s.At
+			p.Runtime, p.Memory = s.Runtime, s.Memory
+			return p
+		}
+		p.Rejected++
+	}
+	return p
+}
+
+// Report is the outcome of a finished interview.
+type Report struct {
+	ID        int64
+	StartedAt time.Time
+	Duration  time.Duration
+	// Used is how long the session actually ran, capped at Duration.
+	Used     time.Duration
+	Problems []ProblemResult
+	Score    int
+}
+
+// ScoreProblem grades one problem out of 100. An accepted solution earns 60
+// plus up to 25 for speed and 15 for clean submissions, less 5 per rejected
+// one. Unsolved problems earn partial credit for passing or at least running
+// local tests.
+func ScoreProblem(r ProblemResult, session time.Duration) int {
+	if !r.Solved {
+		switch {
+		case r.LastTestPassed:
+			return 25
+		case r.TestRuns > 0:
+			return 10
+		default:
+			return 0
+		}
+	}
+	score := 60
+	if session > 0 {
+		left := 1 - float64(r.SolvedAfter)/float64(session)
+		score += int(25 * min(max(left, 0), 1))
+	}
+	score += max(15-5*r.Rejected, 0)
+	return score
+}
+
+// Build scores every problem and averages them into the report's score.
+func Build(r Report) Report {
+	if len(r.Problems) == 0 {
+		return r
+	}
+	total := 0
+	for _, p := range r.Problems {
+		total += ScoreProblem(p, r.Duration)
+	}
+	r.Score = total / len(r.Problems)
+	return r
+}
+
+// Verdict maps a score to a hiring-style recommendation.
+func Verdict(score int) string {
+	switch {
+	case score >= 85:
+		return "Strong hire"
+	case score >= 70:
+		return "Hire"
+	case score >= 50:
+		return "Lean no hire"
+	default:
+		return "No hire"
+	}
+}
+
+// Markdown renders the report for export.
+func (r Report) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Mock interview #%d\n\n", r.ID)
+	fmt.Fprintf(&b, "- Started: %s\n", r.StartedAt.Format("2006-01-02 15:04"))
+	fmt.Fprintf(&b, "- Time used: %s of %s\n", minutes(r.Used), minutes(r.Duration))
+	fmt.Fprintf(&b, "- Score: %d/100 (%s)\n", r.Score, Verdict(r.Score))
+	b.WriteString("\n## Problems\n\n")
+	b.WriteString("| Problem | Difficulty | Result | Solved after | Rejected | Test runs | Score |\n")
+	b.WriteString("|---|---|---|---|---|---|---|\n")
+	for _, p := range r.Problems {
+		result, after := "Unsolved", "-"
+		if p.Solved {
+			result, after = "Accepted", minutes(p.SolvedAfter)
+			if p.Runtime != "" {
+				result += fmt.Sprintf(" (%s, %s)", p.Runtime, p.Memory)
+			}
+		} else if p.LastTestPassed {
+			result = "Local tests passing"
+		}
+		fmt.Fprintf(&b, "| %s (`%s`) | %s | %s | %s | %d | %d | %d |\n",
+			p.Title, p.Slug, p.Difficulty, result, after, p.Rejected, p.TestRuns, ScoreProblem(p, r.Duration))
+	}
+	return b.String()
+}
+
+// minutes formats d as 12m05s, so durations in a report line up.
+func minutes(d time.Duration) string {
+	d = max(d, 0).Round(time.Second)
+	return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+}
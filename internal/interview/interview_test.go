@@ -0,0 +1,81 @@
+package interview
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestScoreProblem(t *testing.T) {
+	session := 60 * time.Minute
+	tests := []struct {
+		name    string
+		r       ProblemResult
+		session time.Duration
+		want    int
+	}{
+		{"untouched", ProblemResult{}, session, 0},
+		{"ran tests", ProblemResult{TestRuns: 3}, session, 10},
+		{"tests passing", ProblemResult{TestRuns: 3, LastTestPassed: true}, session, 25},
+		{"instant clean solve", ProblemResult{Solved: true}, session, 100},
+		{"solved halfway", ProblemResult{Solved: true, SolvedAfter: 30 * time.Minute}, session, 87},
+		{"solved at the end", ProblemResult{Solved: true, SolvedAfter: session}, session, 75},
+		{"two rejections", ProblemResult{Solved: true, SolvedAfter: session, Rejected: 2}, session, 65},
+		{"rejections floor at zero", ProblemResult{Solved: true, SolvedAfter: session, Rejected: 9}, session, 60},
+		{"no session length", ProblemResult{Solved: true, SolvedAfter: time.Minute}, 0, 75},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ScoreProblem(tt.r, tt.session); got != tt.want {
+				t.Errorf("ScoreProblem(%+v, %s) = %d, want %d", tt.r, tt.session, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJudge(t *testing.T) {
+	session := 45 * time.Minute
+	tests := []struct {
+		name         string
+		subs         []Submission
+		wantSolved   bool
+		wantAfter    time.Duration
+		wantRejected int
+	}{
+		{"none", nil, false, 0, 0},
+		{"accepted after a rejection", []Submission{
+			{At: 10 * time.Minute, Status: "Wrong Answer"},
+			{At: 20 * time.Minute, Status: "Pending"},
+			{At: 25 * time.Minute, Status: "Accepted"},
+			{At: 30 * time.Minute, Status: "Wrong Answer"},
+		}, true, 25 * time.Minute, 1},
+		{"late accepted ignored", []Submission{
+			{At: 40 * time.Minute, Status: "Time Limit Exceeded"},
+			{At: 50 * time.Minute, Status: "Accepted"},
+		}, false, 0, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Judge(ProblemResult{Slug: "two-sum"}, tt.subs, session)
+			if got.Solved != tt.wantSolved || got.SolvedAfter != tt.wantAfter || got.Rejected != tt.wantRejected {
+				t.Errorf("Judge = solved %v after %s with %d rejected, want %v after %s with %d",
+					got.Solved, got.SolvedAfter, got.Rejected, tt.wantSolved, tt.wantAfter, tt.wantRejected)
+			}
+		})
+	}
+}
+
+func TestMarkdownDurations(t *testing.T) {
+	r := Build(Report{
+		ID:       1,
+		Duration: time.Hour,
+		Used:     3 * time.Second,
+		Problems: []ProblemResult{{Slug: "two-sum", Title: "Two Sum", Solved: true, SolvedAfter: 90 * time.Second}},
+	})
+	md := r.Markdown()
+	for _, want := range []string{"Time used: 0m03s of 60m00s", "| 1m30s |"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("Markdown() missing %q:\n%s", want, md)
+		}
+	}
+}
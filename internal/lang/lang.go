@@ -0,0 +1,125 @@
+package lang
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+type Language struct {
+	Slug     string
+	Name     string
+	Ext      string
+	Aliases  []string
+	Header   string
+	Fallback string
+	Runner   string
+}
+
+var registry = []Language{
+	{
+		Slug:     "python3",
+		Name:     "Python3",
+		Ext:      ".py",
+		Aliases:  []string{"python", "py"},
+		Fallback: "class Solution:\n    pass\n",
+		Runner:   "python",
+	},
+	{
+		Slug:     "golang",
+		Name:     "Go",
+		Ext:      ".go",
+		Aliases:  []string{"go"},
+		Header:   "package main\n\n",
+		Fallback: "func solve() {\n}\n",
+		Runner:   "go",
+	},
+	{
+		Slug:     "cpp",
+		Name:     "C++",
+		Ext:      ".cpp",
+		Aliases:  []string{"c++", "cc", "cxx"},
+		Fallback: "class Solution {\npublic:\n};\n",
+	},
+	{
+		Slug:     "java",
+		Name:     "Java",
+		Ext:      ".java",
+		Fallback: "class Solution {\n}\n",
+	},
+	{
+		Slug:     "typescript",
+		Name:     "TypeScript",
+		Ext:      ".ts",
+		Aliases:  []string{"ts"},
+		Fallback: "function solve(): void {\n}\n",
+	},
+	{
+		Slug:     "javascript",
+		Name:     "JavaScript",
+		Ext:      ".js",
+		Aliases:  []string{"js"},
+		Fallback: "var solve = function() {\n};\n",
+	},
+}
+
+const Default = "python3"
+
+func Lookup(name string) (Language, error) {
+	key := strings.ToLower(strings.TrimSpace(name))
+	if key == "" {
+		key = Default
+	}
+	for _, l := range registry {
+		if l.Slug == key || strings.ToLower(l.Name) == key {
+			return l, nil
+		}
+		for _, alias := range l.Aliases {
+			if alias == key {
+				return l, nil
+			}
+		}
+	}
+	return Language{}, fmt.Errorf("unsupported language %q (supported: %s)", name, strings.Join(Slugs(), ", "))
+}
+
+func All() []Language {
+	out := make([]Language, len(registry))
+	copy(out, registry)
+	return out
+}
+
+func Slugs() []string {
+	out := make([]string, 0, len(registry))
+	for _, l := range registry {
+		out = append(out, l.Slug)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func (l Language) FileName() string {
+	return "solution" + l.Ext
+}
+
+// Stub returns the file contents to seed a new solution with: the LeetCode
+// snippet for this language when available, prefixed by any header the local
+// toolchain needs (e.g. a Go package clause).
+func (l Language) Stub(snippets map[string]string) string {
+	stub := strings.TrimSpace(snippets[l.Slug])
+	if stub == "" {
+		stub = strings.TrimSpace(l.Fallback)
+	}
+	return l.Header + stub + "\n"
+}
+
+var goPackageClause = regexp.MustCompile(`\A\s*package\s+\w+[ \t]*\n`)
+
+// SubmitCode strips local-only additions before code is sent to LeetCode.
+func (l Language) SubmitCode(code string) string {
+	if l.Slug == "golang" {
+		return strings.TrimLeft(goPackageClause.ReplaceAllString(code, ""), "\n")
+	}
+	return code
+}
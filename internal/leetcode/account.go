@@ -0,0 +1,185 @@
+package leetcode
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type AcceptedSubmission struct {
+	ID        int64
+	Slug      string
+	Title     string
+	Timestamp time.Time
+}
+
+type SubmissionDetail struct {
+	ID        int64
+	Slug      string
+	LangSlug  string
+	Code      string
+	Runtime   string
+	Memory    string
+	Timestamp time.Time
+}
+
+// UserProgress returns the problems the signed-in user has solved or
+// attempted, with Status set to "ac" or "notac".
+func (c *Client) UserProgress(ctx context.Context) ([]Summary, error) {
+	if c.session == "" {
+		return nil, fmt.Errorf("missing auth cookies: %w", ErrUnauthorized)
+	}
+	all, err := c.ListSummaries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Summary, 0)
+	for _, s := range all {
+		if s.Status == "ac" || s.Status == "notac" {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+// RecentAccepted returns the user's most recent accepted submissions, at most
+// one per problem, newest first.
+func (c *Client) RecentAccepted(ctx context.Context, username string, limit int) ([]AcceptedSubmission, error) {
+	var data map[string][]struct {
+		ID        json.Number `json:"id"`
+		Title     string      `json:"title"`
+		TitleSlug string      `json:"titleSlug"`
+		Timestamp json.Number `json:"timestamp"`
+		// Question is only sent by leetcode.cn, which nests the title.
+		Question struct {
+			Title           string `json:"title"`
+			TranslatedTitle string `json:"translatedTitle"`
+			TitleSlug       string `json:"titleSlug"`
+		} `json:"question"`
+	}
+	if err := c.graphql(ctx, "recent submissions", c.site.recentACQuery, map[string]any{"username": username, "limit": limit}, &data); err != nil {
+		return nil, err
+	}
+	list := data[c.site.recentACField]
+	out := make([]AcceptedSubmission, 0, len(list))
+	seen := map[string]bool{}
+	for _, s := range list {
+		if limit > 0 && len(out) == limit {
+			break
+		}
+		slug := firstNonEmpty(s.TitleSlug, s.Question.TitleSlug)
+		if seen[slug] {
+			continue
+		}
+		seen[slug] = true
+		id, _ := s.ID.Int64()
+		ts, _ := s.Timestamp.Int64()
+		out = append(out, AcceptedSubmission{
+			ID:        id,
+			Slug:      slug,
+			Title:     firstNonEmpty(s.Title, c.pickTranslated(s.Question.Title, s.Question.TranslatedTitle)),
+			Timestamp: time.Unix(ts, 0),
+		})
+	}
+	return out, nil
+}
+
+// SubmissionDetail fetches the code and metrics of one of the user's
+// submissions.
+func (c *Client) SubmissionDetail(ctx context.Context, id int64) (SubmissionDetail, error) {
+	var data struct {
+		Detail *struct {
+			Code      string      `json:"code"`
+			Timestamp json.Number `json:"timestamp"`
+			Runtime   string      `json:"runtimeDisplay"`
+			Memory    string      `json:"memoryDisplay"`
+			Lang      struct {
+				Name string `json:"name"`
+			} `json:"lang"`
+			// LangName is leetcode.cn's plain-string lang field.
+			LangName string `json:"langName"`
+			Question struct {
+				TitleSlug string `json:"titleSlug"`
+			} `json:"question"`
+		} `json:"submissionDetails"`
+	}
+	if err := c.graphql(ctx, "submission details", c.site.submissionQuery, map[string]any{"submissionId": id}, &data); err != nil {
+		return SubmissionDetail{}, err
+	}
+	if data.Detail == nil {
+		return SubmissionDetail{}, fmt.Errorf("submission %d: %w", id, ErrNotFound)
+	}
+	d := data.Detail
+	ts, _ := d.Timestamp.Int64()
+	return SubmissionDetail{
+		ID:        id,
+		Slug:      d.Question.TitleSlug,
+		LangSlug:  firstNonEmpty(d.Lang.Name, d.LangName),
+		Code:      d.Code,
+		Runtime:   d.Runtime,
+		Memory:    d.Memory,
+		Timestamp: time.Unix(ts, 0),
+	}, nil
+}
+
+type DailyChallenge struct {
+	Date string
+	// Ends is when the site moves on to the next daily challenge.
+	Ends       time.Time
+	FrontendID string
+	Slug       string
+	Title      string
+	Difficulty string
+	PaidOnly   bool
+}
+
+// DailyChallenge returns today's "Question of the Day". Date is the site's
+// day in YYYY-MM-DD form.
+func (c *Client) DailyChallenge(ctx context.Context) (DailyChallenge, error) {
+	type record struct {
+		Date     string `json:"date"`
+		Question struct {
+			QuestionFrontendID string `json:"questionFrontendId"`
+			Title              string `json:"title"`
+			TranslatedTitle    string `json:"translatedTitle"`
+			TitleSlug          string `json:"titleSlug"`
+			Difficulty         string `json:"difficulty"`
+			PaidOnly           bool   `json:"paidOnly"`
+		} `json:"question"`
+	}
+	var data map[string]json.RawMessage
+	if err := c.graphql(ctx, "daily challenge", c.site.dailyQuery, nil, &data); err != nil {
+		return DailyChallenge{}, err
+	}
+	// leetcode.com returns one record, leetcode.cn a list holding today's.
+	var active record
+	raw := data[c.site.dailyField]
+	var list []record
+	if err := json.Unmarshal(raw, &list); err == nil {
+		if len(list) > 0 {
+			active = list[0]
+		}
+	} else if len(raw) > 0 && string(raw) != "null" {
+		if err := json.Unmarshal(raw, &active); err != nil {
+			return DailyChallenge{}, fmt.Errorf("decode daily challenge: %w", err)
+		}
+	}
+	if active.Question.TitleSlug == "" {
+		return DailyChallenge{}, fmt.Errorf("no active daily challenge")
+	}
+	q := active.Question
+	var ends time.Time
+	if day, err := time.ParseInLocation("2006-01-02", active.Date, c.site.dayZone); err == nil {
+		ends = day.AddDate(0, 0, 1)
+	}
+	return DailyChallenge{
+		Date:       active.Date,
+		Ends:       ends,
+		FrontendID: q.QuestionFrontendID,
+		Slug:       q.TitleSlug,
+		Title:      c.pickTranslated(q.Title, q.TranslatedTitle),
+		Difficulty: q.Difficulty,
+		PaidOnly:   q.PaidOnly,
+	}, nil
+}
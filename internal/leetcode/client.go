@@ -1,24 +1,34 @@
 package leetcode
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
+	"html"
 	"math/rand"
 	"net/http"
 	"net/url"
 	"path"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 )
 
 type Client struct {
-	baseURL string
-	http    *http.Client
-	session string
-	csrf    string
+	baseURL    string
+	http       *http.Client
+	session    string
+	csrf       string
+	limiter    *rateLimiter
+	maxRetries int
+	backoff    time.Duration
+	// pollTimeout bounds how long Submit and RunCode wait for the judge.
+	pollTimeout time.Duration
+	site        site
+	// translated prefers translated titles and statements where the site
+	// serves them (leetcode.cn).
+	translated bool
 }
 
 type Summary struct {
@@ -27,6 +37,26 @@ type Summary struct {
 	Title      string
 	Difficulty string
 	PaidOnly   bool
+	// Status is the signed-in user's progress: "ac" (solved), "notac"
+	// (attempted) or empty.
+	Status string
+	// AcRate and Topics are only filled in by SearchQuestions.
+	AcRate float64
+	Topics []string
+}
+
+// SearchFilter narrows SearchQuestions. Empty fields are ignored.
+type SearchFilter struct {
+	Keyword    string
+	Difficulty string
+	// Tags are topic names or slugs, e.g. "Dynamic Programming" or
+	// "dynamic-programming".
+	Tags []string
+	// Status is the signed-in user's progress: "solved", "attempted" or
+	// "todo".
+	Status string
+	// ListID is a LeetCode problem list (favorite) id.
+	ListID string
 }
 
 type Question struct {
@@ -37,15 +67,59 @@ type Question struct {
 	Difficulty    string
 	StatementHTML string
 	ExampleTests  string
-	Topics        []string
-	PythonStub    string
+	// ExampleOutputs holds the expected "Output:" value of each example in
+	// the statement, in order.
+	ExampleOutputs []string
+	Topics         []string
+	Snippets       map[string]string
+	// Hints are HTML fragments, in the order LeetCode reveals them.
+	Hints    []string
+	Similar  []SimilarQuestion
+	AcRate   float64
+	Likes    int
+	Dislikes int
 }
 
-type SubmitResult struct {
-	SubmissionID int64
+type SimilarQuestion struct {
+	Slug       string `json:"titleSlug"`
+	Title      string `json:"title"`
+	Difficulty string `json:"difficulty"`
+}
+
+type RunCase struct {
+	Input    string
+	Expected string
+	Actual   string
+	Stdout   string
+	Passed   bool
+}
+
+type RunResult struct {
+	InterpretID  string
 	Status       string
 	Runtime      string
 	Memory       string
+	CompileError string
+	RuntimeError string
+	Correct      bool
+	Cases        []RunCase
+}
+
+type SubmitResult struct {
+	SubmissionID      int64
+	Status            string
+	Runtime           string
+	Memory            string
+	RuntimePercentile float64
+	MemoryPercentile  float64
+	TotalCorrect      int
+	TotalTestcases    int
+	LastTestcase      string
+	ExpectedOutput    string
+	CodeOutput        string
+	StdOutput         string
+	CompileError      string
+	RuntimeError      string
 }
 
 func New(baseURL, session, csrf string) *Client {
@@ -53,29 +127,49 @@ func New(baseURL, session, csrf string) *Client {
 		baseURL = "https://leetcode.com"
 	}
 	return &Client{
-		baseURL: strings.TrimRight(baseURL, "/"),
-		http:    &http.Client{Timeout: 30 * time.Second},
-		session: session,
-		csrf:    csrf,
+		baseURL:     strings.TrimRight(baseURL, "/"),
+		http:        &http.Client{Timeout: 30 * time.Second},
+		session:     session,
+		csrf:        csrf,
+		limiter:     &rateLimiter{interval: defaultMinInterval},
+		maxRetries:  defaultMaxRetries,
+		backoff:     defaultBackoff,
+		pollTimeout: defaultPollTimeout,
+		site:        siteFor(baseURL),
+	}
+}
+
+// SetPollTimeout changes how long judge results are polled before Submit and
+// RunCode give up with a "Pending" status. Non-positive values are ignored.
+func (c *Client) SetPollTimeout(d time.Duration) {
+	if d > 0 {
+		c.pollTimeout = d
 	}
 }
 
+// SetTranslated makes Question, SearchQuestions and DailyChallenge return
+// translated titles and statements when the site has them.
+func (c *Client) SetTranslated(on bool) {
+	c.translated = on
+}
+
+// Site names the LeetCode deployment the client talks to.
+func (c *Client) Site() string {
+	return c.site.name
+}
+
 func (c *Client) ListSummaries(ctx context.Context) ([]Summary, error) {
-	u := c.baseURL + "/api/problems/all/"
-	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
-	c.addAuth(req)
-	resp, err := c.http.Do(req)
+	if c.site.listViaGraphQL {
+		return c.listSummariesGraphQL(ctx)
+	}
+	b, err := c.do(ctx, "list summaries", http.MethodGet, c.baseURL+"/api/problems/all/", nil, nil)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode >= 400 {
-		b, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("list summaries failed: %s", string(b))
-	}
 	var raw struct {
 		StatStatusPairs []struct {
-			PaidOnly bool `json:"paid_only"`
+			PaidOnly bool   `json:"paid_only"`
+			Status   string `json:"status"`
 			Stat     struct {
 				QuestionTitleSlug  string `json:"question__title_slug"`
 				QuestionTitle      string `json:"question__title"`
@@ -86,8 +180,8 @@ func (c *Client) ListSummaries(ctx context.Context) ([]Summary, error) {
 			} `json:"difficulty"`
 		} `json:"stat_status_pairs"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
-		return nil, err
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, fmt.Errorf("decode summaries: %w", err)
 	}
 	out := make([]Summary, 0, len(raw.StatStatusPairs))
 	for _, p := range raw.StatStatusPairs {
@@ -97,11 +191,28 @@ func (c *Client) ListSummaries(ctx context.Context) ([]Summary, error) {
 			Title:      p.Stat.QuestionTitle,
 			Difficulty: difficultyLabel(p.Difficulty.Level),
 			PaidOnly:   p.PaidOnly,
+			Status:     p.Status,
 		})
 	}
 	return out, nil
 }
 
+// listSummariesGraphQL pages through the problemset query for sites that do
+// not serve /api/problems/all/.
+func (c *Client) listSummariesGraphQL(ctx context.Context) ([]Summary, error) {
+	out := make([]Summary, 0)
+	for {
+		page, total, err := c.SearchQuestions(ctx, SearchFilter{}, len(out), listPageSize)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, page...)
+		if len(page) == 0 || len(out) >= total {
+			return out, nil
+		}
+	}
+}
+
 func (c *Client) PickRandom(ctx context.Context, difficulty string) (Summary, error) {
 	all, err := c.ListSummaries(ctx)
 	if err != nil {
@@ -123,176 +234,502 @@ func (c *Client) PickRandom(ctx context.Context, difficulty string) (Summary, er
 	return pick[rand.Intn(len(pick))], nil
 }
 
-func (c *Client) Question(ctx context.Context, slug string) (Question, error) {
-	query := `query questionData($titleSlug: String!) { question(titleSlug: $titleSlug) { questionId questionFrontendId title titleSlug difficulty content exampleTestcases topicTags { name } codeSnippets { langSlug code } } }`
-	payload := map[string]any{
-		"query":     query,
-		"variables": map[string]string{"titleSlug": slug},
-	}
-	b, _ := json.Marshal(payload)
-	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/graphql", bytes.NewReader(b))
-	req.Header.Set("Content-Type", "application/json")
-	c.addAuth(req)
-	resp, err := c.http.Do(req)
-	if err != nil {
-		return Question{}, err
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode >= 400 {
-		body, _ := io.ReadAll(resp.Body)
-		return Question{}, fmt.Errorf("question query failed: %s", string(body))
-	}
-
-	var raw struct {
-		Data struct {
-			Question struct {
-				QuestionID         string `json:"questionId"`
-				QuestionFrontendID string `json:"questionFrontendId"`
-				Title              string `json:"title"`
-				TitleSlug          string `json:"titleSlug"`
-				Difficulty         string `json:"difficulty"`
-				Content            string `json:"content"`
-				ExampleTestcases   string `json:"exampleTestcases"`
+// SearchQuestions runs the problemset query with f applied server-side and
+// returns one page of results plus the total number of matches.
+func (c *Client) SearchQuestions(ctx context.Context, f SearchFilter, skip, limit int) ([]Summary, int, error) {
+	filters := map[string]any{}
+	if f.Keyword != "" {
+		filters["searchKeywords"] = f.Keyword
+	}
+	if f.Difficulty != "" {
+		filters["difficulty"] = strings.ToUpper(f.Difficulty)
+	}
+	if len(f.Tags) > 0 {
+		tags := make([]string, 0, len(f.Tags))
+		for _, t := range f.Tags {
+			tags = append(tags, TagSlug(t))
+		}
+		filters["tags"] = tags
+	}
+	switch strings.ToLower(f.Status) {
+	case "":
+	case "solved", "ac":
+		filters["status"] = "AC"
+	case "attempted", "tried", "in_progress":
+		filters["status"] = "TRIED"
+	case "todo", "not_started":
+		filters["status"] = "NOT_STARTED"
+	default:
+		return nil, 0, fmt.Errorf("unknown status filter %q", f.Status)
+	}
+	if f.ListID != "" {
+		filters["listId"] = f.ListID
+	}
+	vars := map[string]any{"categorySlug": "", "skip": skip, "limit": limit, "filters": filters}
+	var data struct {
+		List struct {
+			Total     int `json:"total"`
+			Questions []struct {
+				AcRate             float64 `json:"acRate"`
+				Difficulty         string  `json:"difficulty"`
+				QuestionFrontendID string  `json:"questionFrontendId"`
+				PaidOnly           bool    `json:"isPaidOnly"`
+				Status             string  `json:"status"`
+				Title              string  `json:"title"`
+				TranslatedTitle    string  `json:"translatedTitle"`
+				TitleSlug          string  `json:"titleSlug"`
 				TopicTags          []struct {
 					Name string `json:"name"`
 				} `json:"topicTags"`
-				CodeSnippets []struct {
-					LangSlug string `json:"langSlug"`
-					Code     string `json:"code"`
-				} `json:"codeSnippets"`
-			} `json:"question"`
-		} `json:"data"`
-	}
-	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+			} `json:"questions"`
+		} `json:"problemsetQuestionList"`
+	}
+	if err := c.graphql(ctx, "problemset", c.site.problemsetQuery, vars, &data); err != nil {
+		return nil, 0, err
+	}
+	out := make([]Summary, 0, len(data.List.Questions))
+	for _, q := range data.List.Questions {
+		s := Summary{
+			FrontendID: q.QuestionFrontendID,
+			Slug:       q.TitleSlug,
+			Title:      c.pickTranslated(q.Title, q.TranslatedTitle),
+			Difficulty: normalizeDifficulty(q.Difficulty),
+			PaidOnly:   q.PaidOnly,
+			Status:     normalizeStatus(q.Status),
+			AcRate:     q.AcRate * c.site.acRateScale,
+		}
+		for _, t := range q.TopicTags {
+			s.Topics = append(s.Topics, t.Name)
+		}
+		out = append(out, s)
+	}
+	return out, data.List.Total, nil
+}
+
+// TagSlug turns a topic name such as "Dynamic Programming" into LeetCode's
+// tag slug ("dynamic-programming").
+func TagSlug(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(strings.ReplaceAll(name, "-", " "))), "-")
+}
+
+func (c *Client) Question(ctx context.Context, slug string) (Question, error) {
+	var data struct {
+		Question *struct {
+			QuestionID         string `json:"questionId"`
+			QuestionFrontendID string `json:"questionFrontendId"`
+			Title              string `json:"title"`
+			TranslatedTitle    string `json:"translatedTitle"`
+			TitleSlug          string `json:"titleSlug"`
+			Difficulty         string `json:"difficulty"`
+			PaidOnly           bool   `json:"isPaidOnly"`
+			Content            string `json:"content"`
+			TranslatedContent  string `json:"translatedContent"`
+			ExampleTestcases   string `json:"exampleTestcases"`
+			TopicTags          []struct {
+				Name string `json:"name"`
+			} `json:"topicTags"`
+			Hints []string `json:"hints"`
+			// SimilarQuestions and Stats are JSON documents encoded as strings.
+			SimilarQuestions string `json:"similarQuestions"`
+			Stats            string `json:"stats"`
+			Likes            int    `json:"likes"`
+			Dislikes         int    `json:"dislikes"`
+			CodeSnippets     []struct {
+				LangSlug string `json:"langSlug"`
+				Code     string `json:"code"`
+			} `json:"codeSnippets"`
+		} `json:"question"`
+	}
+	if err := c.graphql(ctx, "question", c.site.questionQuery, map[string]any{"titleSlug": slug}, &data); err != nil {
 		return Question{}, err
 	}
-	q := raw.Data.Question
+	q := data.Question
+	if q == nil || q.TitleSlug == "" {
+		return Question{}, fmt.Errorf("question %q: %w", slug, ErrNotFound)
+	}
+	if q.PaidOnly && q.Content == "" && q.TranslatedContent == "" {
+		return Question{}, fmt.Errorf("question %q: %w", slug, ErrPremiumOnly)
+	}
 	out := Question{
-		FrontendID:    q.QuestionFrontendID,
-		QuestionID:    q.QuestionID,
-		Slug:          q.TitleSlug,
-		Title:         q.Title,
-		Difficulty:    q.Difficulty,
-		StatementHTML: q.Content,
-		ExampleTests:  q.ExampleTestcases,
+		FrontendID:     q.QuestionFrontendID,
+		QuestionID:     q.QuestionID,
+		Slug:           q.TitleSlug,
+		Title:          c.pickTranslated(q.Title, q.TranslatedTitle),
+		Difficulty:     q.Difficulty,
+		StatementHTML:  c.pickTranslated(q.Content, q.TranslatedContent),
+		ExampleTests:   q.ExampleTestcases,
+		ExampleOutputs: ParseExampleOutputs(firstNonEmpty(q.Content, q.TranslatedContent)),
+		Hints:          q.Hints,
+		AcRate:         parseAcRate(q.Stats),
+		Likes:          q.Likes,
+		Dislikes:       q.Dislikes,
+	}
+	if q.SimilarQuestions != "" {
+		_ = json.Unmarshal([]byte(q.SimilarQuestions), &out.Similar)
 	}
 	for _, t := range q.TopicTags {
 		out.Topics = append(out.Topics, t.Name)
 	}
+	out.Snippets = make(map[string]string, len(q.CodeSnippets))
 	for _, cs := range q.CodeSnippets {
-		if cs.LangSlug == "python3" {
-			out.PythonStub = cs.Code
-			break
-		}
+		out.Snippets[cs.LangSlug] = cs.Code
 	}
 	return out, nil
 }
 
 func (c *Client) ValidateAuth(ctx context.Context) (string, error) {
-	query := `query globalData { userStatus { username } }`
-	payload := map[string]any{"query": query}
-	b, _ := json.Marshal(payload)
-	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/graphql", bytes.NewReader(b))
-	req.Header.Set("Content-Type", "application/json")
-	c.addAuth(req)
-	resp, err := c.http.Do(req)
-	if err != nil {
-		return "", err
+	var data struct {
+		UserStatus struct {
+			Username string `json:"username"`
+		} `json:"userStatus"`
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode >= 400 {
-		body, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("auth validation failed: %s", string(body))
-	}
-	var raw struct {
-		Data struct {
-			UserStatus struct {
-				Username string `json:"username"`
-			} `json:"userStatus"`
-		} `json:"data"`
-	}
-	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+	if err := c.graphql(ctx, "auth validation", c.site.userStatusQuery, nil, &data); err != nil {
 		return "", err
 	}
-	if raw.Data.UserStatus.Username == "" {
-		return "", fmt.Errorf("cookie auth failed")
+	if data.UserStatus.Username == "" {
+		return "", fmt.Errorf("cookie auth failed: %w", ErrUnauthorized)
 	}
-	return raw.Data.UserStatus.Username, nil
+	return data.UserStatus.Username, nil
 }
 
-func (c *Client) Submit(ctx context.Context, slug, questionID, code string) (SubmitResult, error) {
+func (c *Client) Submit(ctx context.Context, slug, questionID, langSlug, code string) (SubmitResult, error) {
 	if c.session == "" || c.csrf == "" {
-		return SubmitResult{}, fmt.Errorf("missing auth cookies")
+		return SubmitResult{}, fmt.Errorf("missing auth cookies: %w", ErrUnauthorized)
 	}
 	submitURL := c.baseURL + "/problems/" + slug + "/submit/"
 	body := map[string]any{
-		"lang":        "python3",
+		"lang":        langSlug,
 		"question_id": questionID,
 		"typed_code":  code,
 	}
 	b, _ := json.Marshal(body)
-	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, submitURL, bytes.NewReader(b))
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("x-csrftoken", c.csrf)
-	req.Header.Set("origin", c.baseURL)
-	req.Header.Set("referer", c.baseURL+"/problems/"+slug+"/")
-	c.addAuth(req)
-
-	resp, err := c.http.Do(req)
+	raw, err := c.doOnce(ctx, "submit", http.MethodPost, submitURL, b, c.problemHeaders(slug))
 	if err != nil {
 		return SubmitResult{}, err
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode >= 400 {
-		raw, _ := io.ReadAll(resp.Body)
-		return SubmitResult{}, fmt.Errorf("submit failed: %s", string(raw))
-	}
 	var sr struct {
 		SubmissionID int64 `json:"submission_id"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&sr); err != nil {
-		return SubmitResult{}, err
+	if err := json.Unmarshal(raw, &sr); err != nil {
+		return SubmitResult{}, fmt.Errorf("decode submit response: %w", err)
 	}
 	if sr.SubmissionID == 0 {
 		return SubmitResult{}, fmt.Errorf("no submission id returned")
 	}
+	return c.CheckSubmission(ctx, slug, sr.SubmissionID)
+}
+
+// CheckSubmission waits for the verdict of submission id. It returns a
+// "Pending" result if the judge has not finished within the poll timeout, and
+// the context's error (with SubmissionID set) if ctx is cancelled first.
+func (c *Client) CheckSubmission(ctx context.Context, slug string, id int64) (SubmitResult, error) {
+	chk, done, err := c.pollCheck(ctx, slug, fmt.Sprintf("%d", id))
+	if err != nil {
+		return SubmitResult{SubmissionID: id}, err
+	}
+	if !done {
+		return SubmitResult{SubmissionID: id, Status: "Pending"}, nil
+	}
+	return SubmitResult{
+		SubmissionID:      id,
+		Status:            chk.StatusMsg,
+		Runtime:           chk.Runtime,
+		Memory:            chk.Memory,
+		RuntimePercentile: chk.RuntimePercentile,
+		MemoryPercentile:  chk.MemoryPercentile,
+		TotalCorrect:      chk.TotalCorrect,
+		TotalTestcases:    chk.TotalTestcases,
+		LastTestcase:      string(chk.LastTestcase),
+		ExpectedOutput:    string(chk.ExpectedOutput),
+		CodeOutput:        string(chk.CodeOutput),
+		StdOutput:         string(chk.StdOutput),
+		CompileError:      firstNonEmpty(chk.FullCompileError, chk.CompileError),
+		RuntimeError:      firstNonEmpty(chk.FullRuntimeError, chk.RuntimeError),
+	}, nil
+}
+
+// RunCode runs code against dataInput (LeetCode's newline-separated test
+// input) on the judge's interpreter without creating a submission.
+func (c *Client) RunCode(ctx context.Context, slug, questionID, langSlug, code, dataInput string) (RunResult, error) {
+	if c.session == "" || c.csrf == "" {
+		return RunResult{}, fmt.Errorf("missing auth cookies: %w", ErrUnauthorized)
+	}
+	body := map[string]any{
+		"lang":        langSlug,
+		"question_id": questionID,
+		"typed_code":  code,
+		"data_input":  dataInput,
+	}
+	b, _ := json.Marshal(body)
+	raw, err := c.doOnce(ctx, "run code", http.MethodPost, c.baseURL+"/problems/"+slug+"/interpret_solution/", b, c.problemHeaders(slug))
+	if err != nil {
+		return RunResult{}, err
+	}
+	var ir struct {
+		InterpretID string `json:"interpret_id"`
+	}
+	if err := json.Unmarshal(raw, &ir); err != nil {
+		return RunResult{}, fmt.Errorf("decode run code response: %w", err)
+	}
+	if ir.InterpretID == "" {
+		return RunResult{}, fmt.Errorf("no interpret id returned")
+	}
+
+	chk, done, err := c.pollCheck(ctx, slug, ir.InterpretID)
+	if err != nil {
+		return RunResult{InterpretID: ir.InterpretID}, err
+	}
+	if !done {
+		return RunResult{InterpretID: ir.InterpretID, Status: "Pending"}, nil
+	}
+	out := RunResult{
+		InterpretID:  ir.InterpretID,
+		Status:       chk.StatusMsg,
+		Runtime:      chk.Runtime,
+		Memory:       chk.Memory,
+		CompileError: firstNonEmpty(chk.FullCompileError, chk.CompileError),
+		RuntimeError: firstNonEmpty(chk.FullRuntimeError, chk.RuntimeError),
+		Correct:      chk.CorrectAnswer,
+	}
+	inputs := splitDataInput(dataInput, len(chk.CodeAnswer))
+	for i, actual := range chk.CodeAnswer {
+		rc := RunCase{Actual: actual}
+		if i < len(inputs) {
+			rc.Input = inputs[i]
+		}
+		if i < len(chk.ExpectedCodeAnswer) {
+			rc.Expected = chk.ExpectedCodeAnswer[i]
+		}
+		if i < len(chk.StdOutputList) {
+			rc.Stdout = chk.StdOutputList[i]
+		}
+		if i < len(chk.CompareResult) {
+			rc.Passed = chk.CompareResult[i] == '1'
+		} else {
+			rc.Passed = rc.Expected == rc.Actual
+		}
+		out.Cases = append(out.Cases, rc)
+	}
+	if out.CompileError == "" && out.RuntimeError == "" && !out.Correct && out.Status == "Accepted" {
+		out.Status = "Wrong Answer"
+	}
+	return out, nil
+}
+
+// checkResponse covers both submission and Run Code results; fields whose
+// JSON type differs between the two use the flex types.
+type checkResponse struct {
+	State              string     `json:"state"`
+	StatusMsg          string     `json:"status_msg"`
+	Runtime            string     `json:"status_runtime"`
+	Memory             string     `json:"status_memory"`
+	CompileError       string     `json:"compile_error"`
+	FullCompileError   string     `json:"full_compile_error"`
+	RuntimeError       string     `json:"runtime_error"`
+	FullRuntimeError   string     `json:"full_runtime_error"`
+	CodeAnswer         flexList   `json:"code_answer"`
+	ExpectedCodeAnswer flexList   `json:"expected_code_answer"`
+	StdOutputList      flexList   `json:"std_output_list"`
+	CompareResult      string     `json:"compare_result"`
+	CorrectAnswer      bool       `json:"correct_answer"`
+	RuntimePercentile  float64    `json:"runtime_percentile"`
+	MemoryPercentile   float64    `json:"memory_percentile"`
+	TotalCorrect       int        `json:"total_correct"`
+	TotalTestcases     int        `json:"total_testcases"`
+	LastTestcase       flexString `json:"last_testcase"`
+	ExpectedOutput     flexString `json:"expected_output"`
+	CodeOutput         flexString `json:"code_output"`
+	StdOutput          flexString `json:"std_output"`
+}
+
+// flexString decodes a JSON string or a list of strings (joined by
+// newlines).
+type flexString string
 
-	checkURL := c.baseURL + "/submissions/detail/" + fmt.Sprintf("%d", sr.SubmissionID) + "/check/"
-	deadline := time.Now().Add(40 * time.Second)
-	for time.Now().Before(deadline) {
-		time.Sleep(2 * time.Second)
-		checkReq, _ := http.NewRequestWithContext(ctx, http.MethodGet, checkURL, nil)
-		checkReq.Header.Set("x-csrftoken", c.csrf)
-		checkReq.Header.Set("referer", c.baseURL+"/problems/"+slug+"/")
-		c.addAuth(checkReq)
-		checkResp, err := c.http.Do(checkReq)
+func (f *flexString) UnmarshalJSON(b []byte) error {
+	var l flexList
+	if err := l.UnmarshalJSON(b); err != nil {
+		return err
+	}
+	*f = flexString(strings.Join(l, "\n"))
+	return nil
+}
+
+// flexList decodes a JSON list of strings or a single string.
+type flexList []string
+
+func (f *flexList) UnmarshalJSON(b []byte) error {
+	var list []string
+	if err := json.Unmarshal(b, &list); err == nil {
+		*f = list
+		return nil
+	}
+	var s *string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	*f = nil
+	if s != nil {
+		*f = []string{*s}
+	}
+	return nil
+}
+
+// pollCheck polls the check endpoint shared by submissions and interpreter
+// runs, backing off between polls, until the judge reports a final state, the
+// poll timeout passes or ctx is cancelled.
+func (c *Client) pollCheck(ctx context.Context, slug, id string) (checkResponse, bool, error) {
+	checkURL := c.baseURL + "/submissions/detail/" + id + "/check/"
+	deadline := time.Now().Add(c.pollTimeout)
+	interval := pollInitialInterval
+	for {
+		if err := sleepCtx(ctx, interval); err != nil {
+			return checkResponse{}, false, err
+		}
+		raw, err := c.do(ctx, "check result", http.MethodGet, checkURL, nil, c.problemHeaders(slug))
 		if err != nil {
-			continue
+			return checkResponse{}, false, err
 		}
-		var chk struct {
-			State     string `json:"state"`
-			StatusMsg string `json:"status_msg"`
-			Runtime   string `json:"status_runtime"`
-			Memory    string `json:"memory"`
+		var chk checkResponse
+		if err := json.Unmarshal(raw, &chk); err != nil {
+			return checkResponse{}, false, fmt.Errorf("decode check response: %w", err)
 		}
-		_ = json.NewDecoder(checkResp.Body).Decode(&chk)
-		checkResp.Body.Close()
 		if strings.EqualFold(chk.State, "SUCCESS") || chk.StatusMsg != "" && chk.StatusMsg != "Pending" {
-			return SubmitResult{SubmissionID: sr.SubmissionID, Status: chk.StatusMsg, Runtime: chk.Runtime, Memory: chk.Memory}, nil
+			return chk, true, nil
+		}
+		if time.Now().After(deadline) {
+			return checkResponse{}, false, nil
+		}
+		interval = min(interval*3/2, pollMaxInterval)
+	}
+}
+
+// problemHeaders are the headers LeetCode's judge endpoints expect on
+// requests made from a problem page.
+func (c *Client) problemHeaders(slug string) map[string]string {
+	return map[string]string{
+		"origin":  c.baseURL,
+		"referer": c.baseURL + "/problems/" + slug + "/",
+	}
+}
+
+// splitDataInput divides newline-separated test input evenly into n cases.
+func splitDataInput(dataInput string, n int) []string {
+	var lines []string
+	for _, l := range strings.Split(dataInput, "\n") {
+		if strings.TrimSpace(l) != "" {
+			lines = append(lines, strings.TrimSpace(l))
+		}
+	}
+	if n <= 0 || len(lines)%n != 0 {
+		return nil
+	}
+	per := len(lines) / n
+	out := make([]string, 0, n)
+	for i := 0; i < len(lines); i += per {
+		out = append(out, strings.Join(lines[i:i+per], "\n"))
+	}
+	return out
+}
+
+// pickTranslated returns translated when the client prefers translations and
+// the site sent one, and original otherwise.
+func (c *Client) pickTranslated(original, translated string) string {
+	if c.translated && strings.TrimSpace(translated) != "" {
+		return translated
+	}
+	return original
+}
+
+func firstNonEmpty(vals ...string) string {
+	for _, v := range vals {
+		if strings.TrimSpace(v) != "" {
+			return v
 		}
 	}
-	return SubmitResult{SubmissionID: sr.SubmissionID, Status: "Pending"}, nil
+	return ""
+}
+
+// graphql posts a query to the GraphQL endpoint and decodes the "data" field
+// of the response into out.
+func (c *Client) graphql(ctx context.Context, what, query string, variables map[string]any, out any) error {
+	payload := map[string]any{"query": query}
+	if variables != nil {
+		payload["variables"] = variables
+	}
+	b, _ := json.Marshal(payload)
+	body, err := c.do(ctx, what+" query", http.MethodPost, c.baseURL+c.site.graphqlPath, b, nil)
+	if err != nil {
+		return err
+	}
+	var raw struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return fmt.Errorf("decode %s response: %w", what, err)
+	}
+	if len(raw.Errors) > 0 {
+		return &APIError{Op: what + " query", Body: raw.Errors[0].Message, Err: classifyGraphQLError(raw.Errors[0].Message)}
+	}
+	return json.Unmarshal(raw.Data, out)
 }
 
 func (c *Client) addAuth(req *http.Request) {
 	u, _ := url.Parse(c.baseURL)
-	req.AddCookie(&http.Cookie{Name: "LEETCODE_SESSION", Value: c.session, Path: "/", Domain: u.Hostname()})
-	req.AddCookie(&http.Cookie{Name: "csrftoken", Value: c.csrf, Path: "/", Domain: u.Hostname()})
+	req.AddCookie(&http.Cookie{Name: c.site.sessionCookie, Value: c.session, Path: "/", Domain: u.Hostname()})
+	req.AddCookie(&http.Cookie{Name: c.site.csrfCookie, Value: c.csrf, Path: "/", Domain: u.Hostname()})
 	if c.csrf != "" {
 		req.Header.Set("x-csrftoken", c.csrf)
 	}
 	req.Header.Set("User-Agent", "LeetCLI/0.1")
+	// Keep the trailing slash: leetcode.cn only serves /graphql/.
+	trailing := strings.HasSuffix(req.URL.Path, "/")
 	req.URL.Path = path.Clean(req.URL.Path)
+	if trailing && req.URL.Path != "/" {
+		req.URL.Path += "/"
+	}
+}
+
+var (
+	htmlBreakRe     = regexp.MustCompile(`(?i)<br\s*/?>|</(p|div|pre|li)>`)
+	htmlTagRe       = regexp.MustCompile(`<[^>]*>`)
+	exampleOutputRe = regexp.MustCompile(`(?:Output|输出)\s*[:：][ \t]*(.*)`)
+)
+
+// PlainText strips the markup from an HTML fragment such as a statement or
+// hint, keeping line breaks.
+func PlainText(fragment string) string {
+	text := htmlBreakRe.ReplaceAllString(fragment, "\n")
+	text = html.UnescapeString(htmlTagRe.ReplaceAllString(text, ""))
+	return strings.ReplaceAll(text, "\u00a0", " ")
+}
+
+// ParseExampleOutputs extracts the "Output:" value of each example from a
+// problem statement.
+func ParseExampleOutputs(statementHTML string) []string {
+	var out []string
+	for _, m := range exampleOutputRe.FindAllStringSubmatch(PlainText(statementHTML), -1) {
+		if v := strings.TrimSpace(m[1]); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// parseAcRate reads the acceptance rate out of the question's stats
+// document, e.g. {"acRate": "55.1%"}.
+func parseAcRate(stats string) float64 {
+	var st struct {
+		AcRate string `json:"acRate"`
+	}
+	if json.Unmarshal([]byte(stats), &st) != nil {
+		return 0
+	}
+	v, _ := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(st.AcRate), "%"), 64)
+	return v
 }
 
 func difficultyLabel(level int) string {
@@ -0,0 +1,76 @@
+package leetcode
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+type Contest struct {
+	Slug      string
+	Title     string
+	StartTime time.Time
+	Duration  time.Duration
+	Questions []ContestQuestion
+}
+
+// ContestQuestion is one contest problem; Credit is the points it is worth.
+type ContestQuestion struct {
+	QuestionID string
+	Slug       string
+	Title      string
+	Credit     int
+}
+
+// Contest fetches a past contest (e.g. "weekly-contest-400" or
+// "biweekly-contest-130") with its problems in contest order.
+func (c *Client) Contest(ctx context.Context, slug string) (Contest, error) {
+	b, err := c.do(ctx, "contest info", http.MethodGet, c.baseURL+"/contest/api/info/"+url.PathEscape(slug)+"/", nil, nil)
+	if err != nil {
+		return Contest{}, err
+	}
+	var raw struct {
+		Contest *struct {
+			Title     string      `json:"title"`
+			TitleSlug string      `json:"title_slug"`
+			StartTime json.Number `json:"start_time"`
+			Duration  json.Number `json:"duration"`
+		} `json:"contest"`
+		Questions []struct {
+			QuestionID json.Number `json:"question_id"`
+			Credit     int         `json:"credit"`
+			Title      string      `json:"title"`
+			TitleCN    string      `json:"title_cn"`
+			TitleSlug  string      `json:"title_slug"`
+		} `json:"questions"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return Contest{}, fmt.Errorf("decode contest: %w", err)
+	}
+	if raw.Contest == nil || raw.Contest.TitleSlug == "" {
+		return Contest{}, fmt.Errorf("contest %q: %w", slug, ErrNotFound)
+	}
+	start, _ := raw.Contest.StartTime.Int64()
+	secs, _ := raw.Contest.Duration.Int64()
+	out := Contest{
+		Slug:      raw.Contest.TitleSlug,
+		Title:     raw.Contest.Title,
+		StartTime: time.Unix(start, 0),
+		Duration:  time.Duration(secs) * time.Second,
+	}
+	for _, q := range raw.Questions {
+		out.Questions = append(out.Questions, ContestQuestion{
+			QuestionID: q.QuestionID.String(),
+			Slug:       q.TitleSlug,
+			Title:      c.pickTranslated(q.Title, q.TitleCN),
+			Credit:     q.Credit,
+		})
+	}
+	if len(out.Questions) == 0 {
+		return Contest{}, fmt.Errorf("contest %q has no published problems yet", slug)
+	}
+	return out, nil
+}
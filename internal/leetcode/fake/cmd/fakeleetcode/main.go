@@ -0,0 +1,49 @@
+// Command fakeleetcode runs the fake LeetCode server until interrupted and
+// prints the environment that points leet at it:
+//
+//	go run ./internal/leetcode/fake/cmd/fakeleetcode -verdict two-sum=wrong_answer
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"leetcli/internal/leetcode/fake"
+)
+
+type verdictFlags []string
+
+func (v *verdictFlags) String() string     { return strings.Join(*v, ",") }
+func (v *verdictFlags) Set(s string) error { *v = append(*v, s); return nil }
+
+func main() {
+	var verdicts verdictFlags
+	flag.Var(&verdicts, "verdict", "slug=verdict to judge slug as accepted, wrong_answer or compile_error (repeatable)")
+	pending := flag.Int("pending", 0, "checks answered as pending before the verdict")
+	flag.Parse()
+
+	s := fake.Start()
+	defer s.Close()
+	s.PendingPolls = *pending
+	for _, v := range verdicts {
+		slug, verdict, ok := strings.Cut(v, "=")
+		if !ok {
+			fmt.Fprintf(os.Stderr, "invalid -verdict %q: want slug=verdict\n", v)
+			os.Exit(2)
+		}
+		s.SetVerdict(slug, fake.Verdict(verdict))
+	}
+
+	fmt.Printf("export LEETCODE_SITE=%s\n", s.URL)
+	fmt.Printf("export LEETCODE_SESSION=%s\n", s.Session)
+	fmt.Printf("export CSRFTOKEN=%s\n", s.CSRF)
+	fmt.Fprintln(os.Stderr, "Fake LeetCode is running; press Ctrl+C to stop.")
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	<-stop
+}
@@ -0,0 +1,481 @@
+// Package fake is an in-process stand-in for leetcode.com backed by recorded
+// fixtures. It serves the GraphQL question, problemset, userStatus, recent
+// accepted and submission detail queries, /api/problems/all/, contest info,
+// and the submit, interpret and check endpoints, so commands can be exercised
+// offline:
+//
+//	srv := fake.Start()
+//	defer srv.Close()
+//	cli := srv.Client()                   // or leetcode.New(srv.URL, srv.Session, srv.CSRF)
+//	os.Setenv("LEETCODE_SITE", srv.URL)   // points the leet commands at it
+//
+// cmd/fakeleetcode runs the same server as a standalone process.
+package fake
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"leetcli/internal/leetcode"
+)
+
+//go:embed fixtures
+var fixtures embed.FS
+
+// Default credentials accepted by a new Server.
+const (
+	DefaultSession  = "fake-session"
+	DefaultCSRF     = "fake-csrf"
+	DefaultUsername = "fake-user"
+)
+
+// Verdict names a recorded check response under fixtures/checks and, for
+// Run Code, an entry in fixtures/runs/<slug>.json.
+type Verdict string
+
+const (
+	Accepted     Verdict = "accepted"
+	WrongAnswer  Verdict = "wrong_answer"
+	CompileError Verdict = "compile_error"
+)
+
+// Submission is a submission received by the server, judged with Verdict.
+type Submission struct {
+	ID         int64
+	Slug       string
+	QuestionID string
+	Lang       string
+	Code       string
+	Verdict    Verdict
+	At         time.Time
+}
+
+type Server struct {
+	URL      string
+	Session  string
+	CSRF     string
+	Username string
+	// PendingPolls is how many times the check endpoint answers "STARTED"
+	// before returning the verdict.
+	PendingPolls int
+
+	srv         *httptest.Server
+	mu          sync.Mutex
+	questions   map[string]json.RawMessage
+	problemsAll []byte
+	verdicts    map[string]Verdict
+	submissions []Submission
+	polls       map[string]int
+	nextID      int64
+}
+
+// Start loads the fixtures and starts the server on a loopback port.
+func Start() *Server {
+	s := &Server{
+		Session:   DefaultSession,
+		CSRF:      DefaultCSRF,
+		Username:  DefaultUsername,
+		questions: map[string]json.RawMessage{},
+		verdicts:  map[string]Verdict{},
+		polls:     map[string]int{},
+		nextID:    1000,
+	}
+	s.problemsAll = mustRead("fixtures/problems_all.json")
+	entries, err := fixtures.ReadDir("fixtures/questions")
+	if err != nil {
+		panic(err)
+	}
+	for _, e := range entries {
+		slug := strings.TrimSuffix(e.Name(), ".json")
+		s.questions[slug] = mustRead(path.Join("fixtures/questions", e.Name()))
+	}
+	s.srv = httptest.NewServer(s.routes())
+	s.URL = s.srv.URL
+	return s
+}
+
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Client returns a leetcode.Client signed in to the server.
+func (s *Server) Client() *leetcode.Client {
+	return leetcode.New(s.URL, s.Session, s.CSRF)
+}
+
+// AddQuestion registers a question object in the shape of the GraphQL
+// question query; it replaces a fixture with the same titleSlug.
+func (s *Server) AddQuestion(raw json.RawMessage) error {
+	var q struct {
+		TitleSlug string `json:"titleSlug"`
+	}
+	if err := json.Unmarshal(raw, &q); err != nil {
+		return fmt.Errorf("decode question: %w", err)
+	}
+	if q.TitleSlug == "" {
+		return fmt.Errorf("question has no titleSlug")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.questions[q.TitleSlug] = raw
+	return nil
+}
+
+// SetVerdict makes every later submission and Run Code of slug return v.
+// Both default to Accepted. Run Code answers come from fixtures/runs/<slug>.json,
+// which holds an interpret-shaped check per verdict; CompileError reuses the
+// submit fixture.
+func (s *Server) SetVerdict(slug string, v Verdict) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.verdicts[slug] = v
+}
+
+// AddSubmission records sub in the account's history as if it had been
+// submitted earlier, for the recent accepted and submission detail queries.
+// It returns the submission id.
+func (s *Server) AddSubmission(sub Submission) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextID++
+	sub.ID = s.nextID
+	if sub.Verdict == "" {
+		sub.Verdict = Accepted
+	}
+	if sub.At.IsZero() {
+		sub.At = time.Now()
+	}
+	s.submissions = append(s.submissions, sub)
+	return sub.ID
+}
+
+// Submissions returns the submissions received so far, oldest first.
+func (s *Server) Submissions() []Submission {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Submission(nil), s.submissions...)
+}
+
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /graphql", s.handleGraphQL)
+	mux.HandleFunc("POST /graphql/", s.handleGraphQL)
+	mux.HandleFunc("GET /api/problems/all/", s.handleProblemsAll)
+	mux.HandleFunc("POST /problems/{slug}/submit/", s.handleSubmit)
+	mux.HandleFunc("POST /problems/{slug}/interpret_solution/", s.handleInterpret)
+	mux.HandleFunc("GET /submissions/detail/{id}/check/", s.handleCheck)
+	mux.HandleFunc("GET /contest/api/info/{slug}/", s.handleContest)
+	return mux
+}
+
+func (s *Server) signedIn(r *http.Request) bool {
+	c, err := r.Cookie("LEETCODE_SESSION")
+	return err == nil && c.Value == s.Session
+}
+
+// csrfOK mirrors LeetCode's CSRF check on POSTs to the judge endpoints.
+func (s *Server) csrfOK(r *http.Request) bool {
+	c, err := r.Cookie("csrftoken")
+	return err == nil && c.Value == s.CSRF && r.Header.Get("x-csrftoken") == s.CSRF
+}
+
+func (s *Server) handleGraphQL(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Query     string         `json:"query"`
+		Variables map[string]any `json:"variables"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+	switch {
+	case strings.Contains(req.Query, "userStatus"):
+		username := any(nil)
+		if s.signedIn(r) {
+			username = s.Username
+		}
+		writeData(w, map[string]any{"userStatus": map[string]any{"isSignedIn": username != nil, "username": username}})
+	case strings.Contains(req.Query, "recentAcSubmissionList("):
+		s.handleRecentAccepted(w, req.Variables)
+	case strings.Contains(req.Query, "submissionDetails("):
+		s.handleSubmissionDetail(w, req.Variables)
+	case strings.Contains(req.Query, "questionList("):
+		s.handleProblemset(w, req.Variables)
+	case strings.Contains(req.Query, "question("):
+		slug, _ := req.Variables["titleSlug"].(string)
+		s.mu.Lock()
+		q, ok := s.questions[slug]
+		s.mu.Unlock()
+		if !ok {
+			q = json.RawMessage("null")
+		}
+		writeData(w, map[string]any{"question": q})
+	default:
+		writeJSON(w, map[string]any{"errors": []map[string]string{{"message": "fake: unsupported query"}}})
+	}
+}
+
+// handleProblemset answers the problemset query from the loaded questions,
+// applying the difficulty and keyword filters.
+func (s *Server) handleProblemset(w http.ResponseWriter, vars map[string]any) {
+	filters, _ := vars["filters"].(map[string]any)
+	difficulty, _ := filters["difficulty"].(string)
+	keyword, _ := filters["searchKeywords"].(string)
+
+	type question struct {
+		AcRate             float64           `json:"acRate"`
+		Difficulty         string            `json:"difficulty"`
+		QuestionFrontendID string            `json:"questionFrontendId"`
+		IsPaidOnly         bool              `json:"isPaidOnly"`
+		Title              string            `json:"title"`
+		TitleSlug          string            `json:"titleSlug"`
+		TopicTags          []json.RawMessage `json:"topicTags"`
+	}
+	s.mu.Lock()
+	all := make([]question, 0, len(s.questions))
+	for _, raw := range s.questions {
+		var q question
+		if json.Unmarshal(raw, &q) == nil {
+			all = append(all, q)
+		}
+	}
+	s.mu.Unlock()
+
+	slices.SortFunc(all, func(a, b question) int {
+		x, _ := strconv.Atoi(a.QuestionFrontendID)
+		y, _ := strconv.Atoi(b.QuestionFrontendID)
+		return x - y
+	})
+	matched := make([]map[string]any, 0, len(all))
+	for _, q := range all {
+		if difficulty != "" && !strings.EqualFold(q.Difficulty, difficulty) {
+			continue
+		}
+		if keyword != "" && !strings.Contains(strings.ToLower(q.Title), strings.ToLower(keyword)) {
+			continue
+		}
+		matched = append(matched, map[string]any{
+			"acRate":             q.AcRate,
+			"difficulty":         q.Difficulty,
+			"questionFrontendId": q.QuestionFrontendID,
+			"isPaidOnly":         q.IsPaidOnly,
+			"status":             nil,
+			"title":              q.Title,
+			"titleSlug":          q.TitleSlug,
+			"topicTags":          q.TopicTags,
+		})
+	}
+	skip, limit := intVar(vars["skip"]), intVar(vars["limit"])
+	page := matched[min(skip, len(matched)):]
+	if limit > 0 && len(page) > limit {
+		page = page[:limit]
+	}
+	writeData(w, map[string]any{"problemsetQuestionList": map[string]any{"total": len(matched), "questions": page}})
+}
+
+// handleRecentAccepted lists accepted submissions newest first, like
+// LeetCode's recentAcSubmissionList.
+func (s *Server) handleRecentAccepted(w http.ResponseWriter, vars map[string]any) {
+	limit := intVar(vars["limit"])
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	list := make([]map[string]any, 0)
+	for i := len(s.submissions) - 1; i >= 0; i-- {
+		sub := s.submissions[i]
+		if sub.Verdict != Accepted {
+			continue
+		}
+		if limit > 0 && len(list) == limit {
+			break
+		}
+		var q struct {
+			Title string `json:"title"`
+		}
+		_ = json.Unmarshal(s.questions[sub.Slug], &q)
+		list = append(list, map[string]any{
+			"id":        strconv.FormatInt(sub.ID, 10),
+			"title":     q.Title,
+			"titleSlug": sub.Slug,
+			"timestamp": strconv.FormatInt(sub.At.Unix(), 10),
+		})
+	}
+	writeData(w, map[string]any{"recentAcSubmissionList": list})
+}
+
+func (s *Server) handleSubmissionDetail(w http.ResponseWriter, vars map[string]any) {
+	id := int64(intVar(vars["submissionId"]))
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, sub := range s.submissions {
+		if sub.ID != id {
+			continue
+		}
+		writeData(w, map[string]any{"submissionDetails": map[string]any{
+			"code":           sub.Code,
+			"timestamp":      sub.At.Unix(),
+			"runtimeDisplay": "0 ms",
+			"memoryDisplay":  "17.5 MB",
+			"lang":           map[string]any{"name": sub.Lang},
+			"question":       map[string]any{"titleSlug": sub.Slug},
+		}})
+		return
+	}
+	writeData(w, map[string]any{"submissionDetails": nil})
+}
+
+func (s *Server) handleProblemsAll(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(s.problemsAll)
+}
+
+// handleContest serves fixtures/contests/<slug>.json; the recorded contest
+// is weekly-contest-fake, made of fixture questions.
+func (s *Server) handleContest(w http.ResponseWriter, r *http.Request) {
+	b, err := fixtures.ReadFile(path.Join("fixtures/contests", r.PathValue("slug")+".json"))
+	if err != nil {
+		http.Error(w, `{"error": "contest not found"}`, http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b)
+}
+
+func (s *Server) handleSubmit(w http.ResponseWriter, r *http.Request) {
+	if !s.signedIn(r) || !s.csrfOK(r) {
+		http.Error(w, "CSRF verification failed", http.StatusForbidden)
+		return
+	}
+	var body struct {
+		Lang       string `json:"lang"`
+		QuestionID string `json:"question_id"`
+		TypedCode  string `json:"typed_code"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+	s.mu.Lock()
+	s.nextID++
+	slug := r.PathValue("slug")
+	sub := Submission{ID: s.nextID, Slug: slug, QuestionID: body.QuestionID, Lang: body.Lang, Code: body.TypedCode, Verdict: s.verdicts[slug], At: time.Now()}
+	if sub.Verdict == "" {
+		sub.Verdict = Accepted
+	}
+	s.submissions = append(s.submissions, sub)
+	s.mu.Unlock()
+	writeJSON(w, map[string]any{"submission_id": sub.ID})
+}
+
+// handleInterpret accepts a Run Code request; its check answers with the
+// slug's verdict from fixtures/runs.
+func (s *Server) handleInterpret(w http.ResponseWriter, r *http.Request) {
+	if !s.signedIn(r) || !s.csrfOK(r) {
+		http.Error(w, "CSRF verification failed", http.StatusForbidden)
+		return
+	}
+	_, _ = io.Copy(io.Discard, r.Body)
+	s.mu.Lock()
+	s.nextID++
+	id := "runcode_" + strconv.FormatInt(s.nextID, 10) + "_" + r.PathValue("slug")
+	s.mu.Unlock()
+	writeJSON(w, map[string]any{"interpret_id": id, "test_case": ""})
+}
+
+func (s *Server) handleCheck(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	slug, ok := s.slugFor(id)
+	if !ok {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	s.mu.Lock()
+	s.polls[id]++
+	pending := s.polls[id] <= s.PendingPolls
+	v := s.verdicts[slug]
+	s.mu.Unlock()
+	if pending {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(mustRead("fixtures/checks/pending.json"))
+		return
+	}
+	if v == "" {
+		v = Accepted
+	}
+	var chk map[string]any
+	if strings.HasPrefix(id, "runcode_") && v != CompileError {
+		raw, err := fixtures.ReadFile(path.Join("fixtures/runs", slug+".json"))
+		if err != nil {
+			http.Error(w, "no run fixture for "+slug, http.StatusInternalServerError)
+			return
+		}
+		var runs map[string]map[string]any
+		_ = json.Unmarshal(raw, &runs)
+		if chk = runs[string(v)]; chk == nil {
+			http.Error(w, "unknown verdict "+string(v), http.StatusInternalServerError)
+			return
+		}
+	} else {
+		raw, err := fixtures.ReadFile("fixtures/checks/" + string(v) + ".json")
+		if err != nil {
+			http.Error(w, "unknown verdict "+string(v), http.StatusInternalServerError)
+			return
+		}
+		_ = json.Unmarshal(raw, &chk)
+	}
+	chk["submission_id"] = id
+	writeJSON(w, chk)
+}
+
+// slugFor resolves a submission or interpret id to its problem slug.
+func (s *Server) slugFor(id string) (string, bool) {
+	if rest, ok := strings.CutPrefix(id, "runcode_"); ok {
+		if _, slug, ok := strings.Cut(rest, "_"); ok {
+			return slug, true
+		}
+		return "", false
+	}
+	n, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return "", false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, sub := range s.submissions {
+		if sub.ID == n {
+			return sub.Slug, true
+		}
+	}
+	return "", false
+}
+
+func writeData(w http.ResponseWriter, data any) {
+	writeJSON(w, map[string]any{"data": data})
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func mustRead(name string) []byte {
+	b, err := fixtures.ReadFile(name)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func intVar(v any) int {
+	f, _ := v.(float64)
+	return int(f)
+}
@@ -0,0 +1,45 @@
+package fake
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRunCodeVerdicts(t *testing.T) {
+	tests := []struct {
+		verdict     Verdict
+		wantStatus  string
+		wantCorrect bool
+		wantCases   int
+	}{
+		{Accepted, "Accepted", true, 2},
+		{WrongAnswer, "Wrong Answer", false, 2},
+		{CompileError, "Compile Error", false, 0},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.verdict), func(t *testing.T) {
+			s := Start()
+			defer s.Close()
+			s.SetVerdict("two-sum", tt.verdict)
+
+			res, err := s.Client().RunCode(context.Background(), "two-sum", "1", "python3", "class Solution: pass", "[2,7,11,15]\n9\n[3,2,4]\n6")
+			if err != nil {
+				t.Fatalf("RunCode: %v", err)
+			}
+			if res.Status != tt.wantStatus || res.Correct != tt.wantCorrect || len(res.Cases) != tt.wantCases {
+				t.Errorf("RunCode = status %q correct %v with %d cases, want %q %v with %d", res.Status, res.Correct, len(res.Cases), tt.wantStatus, tt.wantCorrect, tt.wantCases)
+			}
+			if len(s.Submissions()) != 0 {
+				t.Error("Run Code was recorded as a submission")
+			}
+		})
+	}
+}
+
+func TestCheckRejectsUnknownID(t *testing.T) {
+	s := Start()
+	defer s.Close()
+	if _, err := s.Client().CheckSubmission(context.Background(), "two-sum", 42); err == nil {
+		t.Error("CheckSubmission of an unknown id succeeded")
+	}
+}
@@ -0,0 +1,112 @@
+package leetcode
+
+import (
+	"net/url"
+	"strings"
+	"time"
+)
+
+// listPageSize is the page size used when ListSummaries has to page through
+// the problemset query.
+const listPageSize = 100
+
+// site describes one LeetCode deployment: where its endpoints live, which
+// cookies carry the session, and the GraphQL queries it understands. Queries
+// alias fields so that every site decodes into the same response shapes.
+type site struct {
+	name          string
+	graphqlPath   string
+	sessionCookie string
+	csrfCookie    string
+	// listViaGraphQL pages the problemset query instead of calling
+	// /api/problems/all/ for ListSummaries.
+	listViaGraphQL bool
+	// acRateScale turns the site's acRate into a percentage.
+	acRateScale float64
+
+	questionQuery   string
+	problemsetQuery string
+	userStatusQuery string
+	recentACQuery   string
+	// recentACField is the key the recent submissions list is returned under.
+	recentACField   string
+	submissionQuery string
+	dailyQuery      string
+	dailyField      string
+	// dayZone is where the site's day, and so its daily challenge, rolls
+	// over.
+	dayZone *time.Location
+}
+
+var comSite = site{
+	name:            "leetcode.com",
+	graphqlPath:     "/graphql",
+	sessionCookie:   "LEETCODE_SESSION",
+	csrfCookie:      "csrftoken",
+	acRateScale:     1,
+	questionQuery:   `query questionData($titleSlug: String!) { question(titleSlug: $titleSlug) { questionId questionFrontendId title titleSlug difficulty isPaidOnly content exampleTestcases topicTags { name } hints similarQuestions stats likes dislikes codeSnippets { langSlug code } } }`,
+	problemsetQuery: `query problemsetQuestionList($categorySlug: String, $limit: Int, $skip: Int, $filters: QuestionListFilterInput) { problemsetQuestionList: questionList(categorySlug: $categorySlug, limit: $limit, skip: $skip, filters: $filters) { total: totalNum questions: data { acRate difficulty questionFrontendId isPaidOnly status title titleSlug topicTags { name slug } } } }`,
+	userStatusQuery: `query globalData { userStatus { username } }`,
+	recentACQuery:   `query recentAcSubmissions($username: String!, $limit: Int!) { recentAcSubmissionList(username: $username, limit: $limit) { id title titleSlug timestamp } }`,
+	recentACField:   "recentAcSubmissionList",
+	submissionQuery: `query submissionDetails($submissionId: Int!) { submissionDetails(submissionId: $submissionId) { code timestamp runtimeDisplay memoryDisplay lang { name } question { titleSlug } } }`,
+	dailyQuery:      `query questionOfToday { activeDailyCodingChallengeQuestion { date question { questionFrontendId title titleSlug difficulty paidOnly: isPaidOnly } } }`,
+	dailyField:      "activeDailyCodingChallengeQuestion",
+	dayZone:         time.UTC,
+}
+
+// cnSite is leetcode.cn. Its schema names several fields differently, lists
+// problems only through GraphQL, identifies users by userSlug and returns
+// today's question as a one-element list.
+var cnSite = site{
+	name:            "leetcode.cn",
+	graphqlPath:     "/graphql/",
+	sessionCookie:   "LEETCODE_SESSION",
+	csrfCookie:      "csrftoken",
+	listViaGraphQL:  true,
+	acRateScale:     100,
+	questionQuery:   `query questionData($titleSlug: String!) { question(titleSlug: $titleSlug) { questionId questionFrontendId title translatedTitle titleSlug difficulty isPaidOnly content translatedContent exampleTestcases topicTags { name } hints similarQuestions stats likes dislikes codeSnippets { langSlug code } } }`,
+	problemsetQuery: `query problemsetQuestionList($categorySlug: String, $limit: Int, $skip: Int, $filters: QuestionListFilterInput) { problemsetQuestionList(categorySlug: $categorySlug, limit: $limit, skip: $skip, filters: $filters) { total questions { acRate difficulty questionFrontendId: frontendQuestionId isPaidOnly: paidOnly status title translatedTitle: titleCn titleSlug topicTags { name slug } } } }`,
+	userStatusQuery: `query globalData { userStatus { username: userSlug } }`,
+	recentACQuery:   `query recentAcSubmissions($username: String!) { recentACSubmissions(userSlug: $username) { id: submissionId timestamp: submitTime question { title translatedTitle titleSlug } } }`,
+	recentACField:   "recentACSubmissions",
+	submissionQuery: `query submissionDetail($submissionId: ID!) { submissionDetails: submissionDetail(submissionId: $submissionId) { code timestamp runtimeDisplay: runtime memoryDisplay: memory langName: lang question { titleSlug } } }`,
+	dailyQuery:      `query questionOfToday { todayRecord { date question { questionFrontendId title translatedTitle titleSlug difficulty paidOnly: isPaidOnly } } }`,
+	dailyField:      "todayRecord",
+	dayZone:         time.FixedZone("UTC+8", 8*60*60),
+}
+
+// siteFor picks the adapter for baseURL by host name; anything that is not
+// leetcode.cn is treated as leetcode.com.
+func siteFor(baseURL string) site {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return comSite
+	}
+	host := strings.ToLower(u.Hostname())
+	if host == "leetcode.cn" || strings.HasSuffix(host, ".leetcode.cn") {
+		return cnSite
+	}
+	return comSite
+}
+
+// normalizeStatus maps the problemset status of either site to the
+// "ac"/"notac" values used by /api/problems/all/.
+func normalizeStatus(s string) string {
+	switch strings.ToUpper(s) {
+	case "AC":
+		return "ac"
+	case "NOTAC", "TRIED":
+		return "notac"
+	default:
+		return ""
+	}
+}
+
+// normalizeDifficulty turns "EASY" and friends into "Easy".
+func normalizeDifficulty(d string) string {
+	if d == "" {
+		return d
+	}
+	return strings.ToUpper(d[:1]) + strings.ToLower(d[1:])
+}
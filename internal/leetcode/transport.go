@@ -0,0 +1,247 @@
+package leetcode
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrUnauthorized = errors.New("not signed in to LeetCode or session expired")
+	ErrRateLimited  = errors.New("rate limited by LeetCode")
+	ErrPremiumOnly  = errors.New("problem requires LeetCode Premium")
+	ErrNotFound     = errors.New("not found on LeetCode")
+)
+
+// APIError is a failed LeetCode request. It unwraps to one of the Err*
+// sentinels when the failure is recognised.
+type APIError struct {
+	Op         string
+	StatusCode int
+	Body       string
+	Err        error
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%s failed", e.Op)
+	if e.StatusCode != 0 {
+		msg += fmt.Sprintf(" (HTTP %d)", e.StatusCode)
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	} else if body := strings.TrimSpace(e.Body); body != "" {
+		if len(body) > 200 {
+			body = body[:200] + "..."
+		}
+		msg += ": " + body
+	}
+	return msg
+}
+
+func (e *APIError) Unwrap() error { return e.Err }
+
+const (
+	defaultMaxRetries  = 3
+	defaultBackoff     = 500 * time.Millisecond
+	maxBackoff         = 30 * time.Second
+	defaultMinInterval = 250 * time.Millisecond
+
+	defaultPollTimeout  = 120 * time.Second
+	pollInitialInterval = time.Second
+	pollMaxInterval     = 5 * time.Second
+)
+
+// rateLimiter spaces requests at least interval apart.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+func (l *rateLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	at := l.next
+	if at.Before(now) {
+		at = now
+	}
+	l.next = at.Add(l.interval)
+	l.mu.Unlock()
+	return sleepCtx(ctx, time.Until(at))
+}
+
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// do sends an idempotent request through the rate limiter, retrying network
+// errors, 429/499 and 5xx responses with exponential backoff (or the
+// server's Retry-After), and returns the body of a successful response.
+func (c *Client) do(ctx context.Context, op, method, u string, body []byte, headers map[string]string) ([]byte, error) {
+	return c.send(ctx, op, method, u, body, headers, true)
+}
+
+// doOnce is do for requests that must not run twice, such as submissions:
+// it only retries when the server cannot have processed the request (a
+// failed connection or a 429/499 rejection).
+func (c *Client) doOnce(ctx context.Context, op, method, u string, body []byte, headers map[string]string) ([]byte, error) {
+	return c.send(ctx, op, method, u, body, headers, false)
+}
+
+func (c *Client) send(ctx context.Context, op, method, u string, body []byte, headers map[string]string, idempotent bool) ([]byte, error) {
+	var lastErr error
+	for attempt := 0; attempt <= c.maxRetries; attempt++ {
+		if attempt > 0 {
+			if err := sleepCtx(ctx, c.retryDelay(attempt, lastErr)); err != nil {
+				return nil, err
+			}
+		}
+		if err := c.limiter.wait(ctx); err != nil {
+			return nil, err
+		}
+		var rd io.Reader
+		if body != nil {
+			rd = bytes.NewReader(body)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, u, rd)
+		if err != nil {
+			return nil, err
+		}
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		c.addAuth(req)
+
+		resp, err := c.http.Do(req)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			lastErr = &APIError{Op: op, Err: err}
+			if !idempotent && !dialFailed(err) {
+				return nil, lastErr
+			}
+			continue
+		}
+		raw, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode < 300 && readErr == nil {
+			return raw, nil
+		}
+		apiErr := &APIError{Op: op, StatusCode: resp.StatusCode, Body: string(raw), Err: classifyStatus(resp.StatusCode, string(raw))}
+		if readErr != nil {
+			apiErr.Err = readErr
+		}
+		if !retryable(resp.StatusCode) && readErr == nil {
+			return nil, apiErr
+		}
+		if !idempotent && !errors.Is(apiErr.Err, ErrRateLimited) {
+			return nil, apiErr
+		}
+		lastErr = &retryAfterError{APIError: apiErr, after: parseRetryAfter(resp.Header.Get("Retry-After"))}
+	}
+	var ra *retryAfterError
+	if errors.As(lastErr, &ra) {
+		return nil, ra.APIError
+	}
+	return nil, lastErr
+}
+
+type retryAfterError struct {
+	*APIError
+	after time.Duration
+}
+
+func (c *Client) retryDelay(attempt int, lastErr error) time.Duration {
+	var ra *retryAfterError
+	if errors.As(lastErr, &ra) && ra.after > 0 {
+		return min(ra.after, maxBackoff)
+	}
+	d := c.backoff << (attempt - 1)
+	if d > maxBackoff {
+		d = maxBackoff
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// dialFailed reports whether err happened before the request reached the
+// server.
+func dialFailed(err error) bool {
+	var op *net.OpError
+	return errors.As(err, &op) && op.Op == "dial"
+}
+
+func retryable(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, 499, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout, http.StatusInternalServerError:
+		return true
+	}
+	return false
+}
+
+func classifyStatus(status int, body string) error {
+	switch {
+	case status == http.StatusTooManyRequests || status == 499:
+		return ErrRateLimited
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		if strings.Contains(strings.ToLower(body), "csrf") {
+			return fmt.Errorf("%w (CSRF token rejected)", ErrUnauthorized)
+		}
+		return ErrUnauthorized
+	case status == http.StatusNotFound:
+		return ErrNotFound
+	}
+	return nil
+}
+
+func classifyGraphQLError(msg string) error {
+	m := strings.ToLower(msg)
+	switch {
+	case strings.Contains(m, "not authenticated") || strings.Contains(m, "login") || strings.Contains(m, "permission"):
+		return ErrUnauthorized
+	case strings.Contains(m, "does not exist") || strings.Contains(m, "not found"):
+		return ErrNotFound
+	case strings.Contains(m, "premium") || strings.Contains(m, "subscription"):
+		return ErrPremiumOnly
+	case strings.Contains(m, "too many") || strings.Contains(m, "rate"):
+		return ErrRateLimited
+	}
+	return nil
+}
+
+// parseRetryAfter accepts both delay-seconds and HTTP-date forms.
+func parseRetryAfter(v string) time.Duration {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t)
+	}
+	return 0
+}
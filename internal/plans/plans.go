@@ -0,0 +1,147 @@
+// Package plans loads curated study plans: ordered problem lists split into
+// sections. Bundled plans are embedded; custom plans are YAML or JSON files
+// in the workspace plans directory.
+package plans
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed builtin/*.yaml
+var builtinFS embed.FS
+
+type Plan struct {
+	ID          string    `yaml:"id" json:"id"`
+	Name        string    `yaml:"name" json:"name"`
+	Description string    `yaml:"description" json:"description"`
+	Sections    []Section `yaml:"sections" json:"sections"`
+	// Custom is set for plans loaded from the plans directory.
+	Custom bool `yaml:"-" json:"-"`
+}
+
+type Section struct {
+	Name  string   `yaml:"name" json:"name"`
+	Slugs []string `yaml:"problems" json:"problems"`
+}
+
+// Slugs returns every problem of the plan in order, without duplicates.
+func (p Plan) Slugs() []string {
+	seen := map[string]bool{}
+	out := make([]string, 0)
+	for _, s := range p.Sections {
+		for _, slug := range s.Slugs {
+			if !seen[slug] {
+				seen[slug] = true
+				out = append(out, slug)
+			}
+		}
+	}
+	return out
+}
+
+// Builtin returns the bundled plans.
+func Builtin() ([]Plan, error) {
+	entries, err := fs.ReadDir(builtinFS, "builtin")
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Plan, 0, len(entries))
+	for _, e := range entries {
+		data, err := builtinFS.ReadFile("builtin/" + e.Name())
+		if err != nil {
+			return nil, err
+		}
+		p, err := parse(e.Name(), data)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, p)
+	}
+	return out, nil
+}
+
+// Load returns the bundled plans followed by the custom plans in dir. A
+// custom plan with the id of a bundled one replaces it. A missing dir is
+// not an error.
+func Load(dir string) ([]Plan, error) {
+	all, err := Builtin()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("read plans dir: %w", err)
+	}
+	custom := make([]Plan, 0)
+	for _, e := range entries {
+		ext := strings.ToLower(filepath.Ext(e.Name()))
+		if e.IsDir() || (ext != ".yaml" && ext != ".yml" && ext != ".json") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		p, err := parse(e.Name(), data)
+		if err != nil {
+			return nil, err
+		}
+		p.Custom = true
+		custom = append(custom, p)
+	}
+	sort.Slice(custom, func(i, j int) bool { return custom[i].ID < custom[j].ID })
+	for _, p := range custom {
+		replaced := false
+		for i := range all {
+			if all[i].ID == p.ID {
+				all[i] = p
+				replaced = true
+			}
+		}
+		if !replaced {
+			all = append(all, p)
+		}
+	}
+	return all, nil
+}
+
+// Find returns the plan with the given id.
+func Find(all []Plan, id string) (Plan, error) {
+	for _, p := range all {
+		if strings.EqualFold(p.ID, id) {
+			return p, nil
+		}
+	}
+	ids := make([]string, 0, len(all))
+	for _, p := range all {
+		ids = append(ids, p.ID)
+	}
+	return Plan{}, fmt.Errorf("unknown plan %q (available: %s)", id, strings.Join(ids, ", "))
+}
+
+// parse reads a plan file. JSON is valid YAML, so one decoder handles both;
+// the id defaults to the file name.
+func parse(name string, data []byte) (Plan, error) {
+	var p Plan
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return Plan{}, fmt.Errorf("parse plan %s: %w", name, err)
+	}
+	if p.ID == "" {
+		p.ID = strings.TrimSuffix(name, filepath.Ext(name))
+	}
+	if p.Name == "" {
+		p.Name = p.ID
+	}
+	if len(p.Slugs()) == 0 {
+		return Plan{}, fmt.Errorf("plan %s has no problems", name)
+	}
+	return p, nil
+}
@@ -0,0 +1,92 @@
+// Package srs schedules problem reviews with the SM-2 spaced-repetition
+// algorithm, grading each re-solve from how it went.
+package srs
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	DefaultEase = 2.5
+	minEase     = 1.3
+	// PassingGrade is the lowest grade that counts as remembered.
+	PassingGrade = 3
+	// defaultBaseline stands in for the original solve time when none was
+	// recorded.
+	defaultBaseline = 30 * time.Minute
+)
+
+// Card is the scheduling state of one problem.
+type Card struct {
+	Repetitions  int
+	IntervalDays int
+	Ease         float64
+}
+
+// Attempt describes one review re-solve.
+type Attempt struct {
+	Elapsed  time.Duration
+	Baseline time.Duration
+	// Failures counts failing local test runs and rejected submissions.
+	Failures int
+	Hints    int
+	Solved   bool
+}
+
+// Grade scores an attempt on SM-2's 0-5 scale. Speed relative to the
+// original solve sets the base grade; each failure and each hint costs a
+// point, at most two points apiece. An unsolved attempt grades 0.
+func Grade(at Attempt) int {
+	if !at.Solved {
+		return 0
+	}
+	baseline := at.Baseline
+	if baseline <= 0 {
+		baseline = defaultBaseline
+	}
+	ratio := float64(at.Elapsed) / float64(baseline)
+	grade := 1
+	switch {
+	case ratio <= 0.5:
+		grade = 5
+	case ratio <= 1:
+		grade = 4
+	case ratio <= 1.5:
+		grade = 3
+	case ratio <= 2.5:
+		grade = 2
+	}
+	grade -= min(at.Failures, 2) + min(at.Hints, 2)
+	return max(grade, 1)
+}
+
+// Next returns the card after a review graded grade (0-5).
+func (c Card) Next(grade int) Card {
+	grade = min(max(grade, 0), 5)
+	if c.Ease == 0 {
+		c.Ease = DefaultEase
+	}
+	if grade < PassingGrade {
+		c.Repetitions = 0
+		c.IntervalDays = 1
+	} else {
+		c.Repetitions++
+		switch c.Repetitions {
+		case 1:
+			c.IntervalDays = 1
+		case 2:
+			c.IntervalDays = 6
+		default:
+			c.IntervalDays = int(math.Round(float64(c.IntervalDays) * c.Ease))
+		}
+	}
+	q := float64(5 - grade)
+	c.Ease = math.Max(minEase, c.Ease+0.1-q*(0.08+q*0.02))
+	return c
+}
+
+// Due is the day the card is next due after a review on day.
+func (c Card) Due(day time.Time) time.Time {
+	return day.AddDate(0, 0, c.IntervalDays)
+}
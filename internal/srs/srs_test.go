@@ -0,0 +1,76 @@
+package srs
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGrade(t *testing.T) {
+	base := 20 * time.Minute
+	tests := []struct {
+		name string
+		at   Attempt
+		want int
+	}{
+		{"unsolved", Attempt{Elapsed: time.Minute, Baseline: base}, 0},
+		{"twice as fast", Attempt{Elapsed: 10 * time.Minute, Baseline: base, Solved: true}, 5},
+		{"as fast", Attempt{Elapsed: 20 * time.Minute, Baseline: base, Solved: true}, 4},
+		{"half again slower", Attempt{Elapsed: 30 * time.Minute, Baseline: base, Solved: true}, 3},
+		{"over twice as slow", Attempt{Elapsed: 50 * time.Minute, Baseline: base, Solved: true}, 2},
+		{"far slower", Attempt{Elapsed: 2 * time.Hour, Baseline: base, Solved: true}, 1},
+		{"one failure", Attempt{Elapsed: 10 * time.Minute, Baseline: base, Failures: 1, Solved: true}, 4},
+		{"failures capped at two", Attempt{Elapsed: 10 * time.Minute, Baseline: base, Failures: 5, Solved: true}, 3},
+		{"hints capped at two", Attempt{Elapsed: 10 * time.Minute, Baseline: base, Hints: 3, Solved: true}, 3},
+		{"floor of one", Attempt{Elapsed: 40 * time.Minute, Baseline: base, Failures: 2, Hints: 2, Solved: true}, 1},
+		{"default baseline", Attempt{Elapsed: 15 * time.Minute, Solved: true}, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Grade(tt.at); got != tt.want {
+				t.Errorf("Grade(%+v) = %d, want %d", tt.at, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCardNext(t *testing.T) {
+	tests := []struct {
+		name   string
+		card   Card
+		grades []int
+		want   Card
+	}{
+		{"first pass", Card{}, []int{4}, Card{Repetitions: 1, IntervalDays: 1, Ease: 2.5}},
+		{"second pass", Card{}, []int{4, 4}, Card{Repetitions: 2, IntervalDays: 6, Ease: 2.5}},
+		{"third pass multiplies by ease", Card{}, []int{4, 4, 4}, Card{Repetitions: 3, IntervalDays: 15, Ease: 2.5}},
+		{"perfect grades raise ease", Card{}, []int{5, 5}, Card{Repetitions: 2, IntervalDays: 6, Ease: 2.7}},
+		{"barely passing lowers ease", Card{}, []int{3}, Card{Repetitions: 1, IntervalDays: 1, Ease: 2.36}},
+		{"failure resets", Card{Repetitions: 4, IntervalDays: 30, Ease: 2.5}, []int{2}, Card{Repetitions: 0, IntervalDays: 1, Ease: 2.18}},
+		{"ease floor", Card{Ease: 1.3}, []int{0}, Card{Repetitions: 0, IntervalDays: 1, Ease: 1.3}},
+		{"grade clamped", Card{}, []int{9}, Card{Repetitions: 1, IntervalDays: 1, Ease: 2.6}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.card
+			for _, g := range tt.grades {
+				got = got.Next(g)
+			}
+			if got.Repetitions != tt.want.Repetitions || got.IntervalDays != tt.want.IntervalDays || !near(got.Ease, tt.want.Ease) {
+				t.Errorf("after grades %v: %+v, want %+v", tt.grades, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCardDue(t *testing.T) {
+	day := time.Date(2024, 2, 27, 9, 0, 0, 0, time.UTC)
+	got := Card{IntervalDays: 3}.Due(day)
+	if want := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("Due = %v, want %v", got, want)
+	}
+}
+
+func near(a, b float64) bool {
+	d := a - b
+	return d < 1e-9 && d > -1e-9
+}
@@ -0,0 +1,111 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ContestRun is a virtual replay of a past contest.
+type ContestRun struct {
+	ID          int64
+	ContestSlug string
+	Title       string
+	StartedAt   time.Time
+	DurationMin int
+	Problems    []ContestProblem
+	// FinishedAt is zero while the run is in progress.
+	FinishedAt time.Time
+	Score      int
+	FinishSec  int
+	Penalties  int
+}
+
+type ContestProblem struct {
+	Slug   string `json:"slug"`
+	Title  string `json:"title"`
+	Credit int    `json:"credit"`
+}
+
+// Deadline is when the run's shared clock runs out.
+func (r ContestRun) Deadline() time.Time {
+	return r.StartedAt.Add(time.Duration(r.DurationMin) * time.Minute)
+}
+
+// Has reports whether slug is one of the run's problems.
+func (r ContestRun) Has(slug string) bool {
+	for _, p := range r.Problems {
+		if p.Slug == slug {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Store) StartContestRun(ctx context.Context, contestSlug, title string, durationMin int, problems []ContestProblem) (ContestRun, error) {
+	raw, _ := json.Marshal(problems)
+	res, err := s.db.ExecContext(ctx, `INSERT INTO contest_runs(contest_slug, title, started_unix, duration_min, problems_json) VALUES(?, ?, ?, ?, ?)`,
+		contestSlug, title, time.Now().Unix(), durationMin, string(raw))
+	if err != nil {
+		return ContestRun{}, fmt.Errorf("start contest run: %w", err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return ContestRun{}, err
+	}
+	_, _ = s.db.ExecContext(ctx, `INSERT INTO activity(slug, kind, payload) VALUES('', 'contest_start', ?)`, contestSlug)
+	return scanContestRun(s.db.QueryRowContext(ctx, `SELECT `+contestRunColumns+` FROM contest_runs WHERE id=?`, id))
+}
+
+const contestRunColumns = `id, contest_slug, title, started_unix, duration_min, problems_json, COALESCE(finished_unix, 0), score, finish_sec, penalties`
+
+func scanContestRun(row rowScanner) (ContestRun, error) {
+	var r ContestRun
+	var started, finished int64
+	var problemsJSON string
+	if err := row.Scan(&r.ID, &r.ContestSlug, &r.Title, &started, &r.DurationMin, &problemsJSON, &finished, &r.Score, &r.FinishSec, &r.Penalties); err != nil {
+		return ContestRun{}, err
+	}
+	r.StartedAt = time.Unix(started, 0)
+	if finished > 0 {
+		r.FinishedAt = time.Unix(finished, 0)
+	}
+	_ = json.Unmarshal([]byte(problemsJSON), &r.Problems)
+	return r, nil
+}
+
+// ActiveContestRun returns the unfinished contest run, or sql.ErrNoRows when
+// none is in progress.
+func (s *Store) ActiveContestRun(ctx context.Context) (ContestRun, error) {
+	return scanContestRun(s.db.QueryRowContext(ctx, `SELECT `+contestRunColumns+` FROM contest_runs WHERE finished_unix IS NULL ORDER BY id DESC LIMIT 1`))
+}
+
+// ListContestRuns returns contest runs, newest first.
+func (s *Store) ListContestRuns(ctx context.Context) ([]ContestRun, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT `+contestRunColumns+` FROM contest_runs ORDER BY id DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("list contest runs: %w", err)
+	}
+	defer rows.Close()
+	out := make([]ContestRun, 0)
+	for rows.Next() {
+		r, err := scanContestRun(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, r)
+	}
+	return out, rows.Err()
+}
+
+// FinishContestRun records the final score, finish time (with penalties)
+// and penalty count of run id.
+func (s *Store) FinishContestRun(ctx context.Context, id int64, score, finishSec, penalties int) error {
+	_, err := s.db.ExecContext(ctx, `UPDATE contest_runs SET finished_unix=?, score=?, finish_sec=?, penalties=? WHERE id=?`, time.Now().Unix(), score, finishSec, penalties, id)
+	if err != nil {
+		return fmt.Errorf("finish contest run: %w", err)
+	}
+	_, _ = s.db.ExecContext(ctx, `INSERT INTO activity(slug, kind, payload) VALUES('', 'contest', ?)`, fmt.Sprintf("id=%d score=%d", id, score))
+	return nil
+}
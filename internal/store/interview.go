@@ -0,0 +1,173 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Interview is a timed mock interview session over a few problems.
+type Interview struct {
+	ID          int64
+	StartedAt   time.Time
+	DurationMin int
+	Difficulty  string
+	Slugs       []string
+	// FinishedAt is zero while the interview is running.
+	FinishedAt time.Time
+	Score      int
+	Report     string
+}
+
+// Deadline is when the interview's countdown runs out.
+func (iv Interview) Deadline() time.Time {
+	return iv.StartedAt.Add(time.Duration(iv.DurationMin) * time.Minute)
+}
+
+// TimerSlug names the timer session that holds the interview's clock.
+func (iv Interview) TimerSlug() string {
+	return fmt.Sprintf("interview-%d", iv.ID)
+}
+
+// Has reports whether slug is one of the interview's problems.
+func (iv Interview) Has(slug string) bool {
+	for _, s := range iv.Slugs {
+		if s == slug {
+			return true
+		}
+	}
+	return false
+}
+
+// StartInterview records a new interview over slugs and hides their
+// difficulty, topics and hints until it finishes.
+func (s *Store) StartInterview(ctx context.Context, durationMin int, difficulty string, slugs []string) (Interview, error) {
+	raw, _ := json.Marshal(slugs)
+	now := time.Now()
+	res, err := s.db.ExecContext(ctx, `INSERT INTO interviews(started_unix, duration_min, difficulty, slugs_json) VALUES(?, ?, ?, ?)`, now.Unix(), durationMin, difficulty, string(raw))
+	if err != nil {
+		return Interview{}, fmt.Errorf("start interview: %w", err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return Interview{}, err
+	}
+	if err := s.SetBlind(ctx, slugs, true); err != nil {
+		return Interview{}, err
+	}
+	_, _ = s.db.ExecContext(ctx, `INSERT INTO activity(slug, kind, payload) VALUES('', 'interview_start', ?)`, fmt.Sprintf("id=%d", id))
+	return s.GetInterview(ctx, id)
+}
+
+const interviewColumns = `id, started_unix, duration_min, difficulty, slugs_json, COALESCE(finished_unix, 0), score, report`
+
+func scanInterview(row rowScanner) (Interview, error) {
+	var iv Interview
+	var started, finished int64
+	var slugsJSON string
+	if err := row.Scan(&iv.ID, &started, &iv.DurationMin, &iv.Difficulty, &slugsJSON, &finished, &iv.Score, &iv.Report); err != nil {
+		return Interview{}, err
+	}
+	iv.StartedAt = time.Unix(started, 0)
+	if finished > 0 {
+		iv.FinishedAt = time.Unix(finished, 0)
+	}
+	_ = json.Unmarshal([]byte(slugsJSON), &iv.Slugs)
+	return iv, nil
+}
+
+func (s *Store) GetInterview(ctx context.Context, id int64) (Interview, error) {
+	return scanInterview(s.db.QueryRowContext(ctx, `SELECT `+interviewColumns+` FROM interviews WHERE id=?`, id))
+}
+
+// ActiveInterview returns the unfinished interview, or sql.ErrNoRows when
+// none is running. An interview past its deadline is still active until it
+// is finished.
+func (s *Store) ActiveInterview(ctx context.Context) (Interview, error) {
+	return scanInterview(s.db.QueryRowContext(ctx, `SELECT `+interviewColumns+` FROM interviews WHERE finished_unix IS NULL ORDER BY id DESC LIMIT 1`))
+}
+
+// ListInterviews returns past and running interviews, newest first.
+func (s *Store) ListInterviews(ctx context.Context) ([]Interview, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT `+interviewColumns+` FROM interviews ORDER BY id DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("list interviews: %w", err)
+	}
+	defer rows.Close()
+	out := make([]Interview, 0)
+	for rows.Next() {
+		iv, err := scanInterview(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, iv)
+	}
+	return out, rows.Err()
+}
+
+// FinishInterview stores the interview's score and markdown report and
+// reveals its problems again.
+func (s *Store) FinishInterview(ctx context.Context, id int64, score int, report string) error {
+	iv, err := s.GetInterview(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("interview %d not found", id)
+		}
+		return err
+	}
+	_, err = s.db.ExecContext(ctx, `UPDATE interviews SET finished_unix=?, score=?, report=? WHERE id=?`, time.Now().Unix(), score, report, id)
+	if err != nil {
+		return fmt.Errorf("finish interview: %w", err)
+	}
+	if err := s.SetBlind(ctx, iv.Slugs, false); err != nil {
+		return err
+	}
+	_, _ = s.db.ExecContext(ctx, `INSERT INTO activity(slug, kind, payload) VALUES('', 'interview', ?)`, fmt.Sprintf("id=%d score=%d", id, score))
+	return nil
+}
+
+// SetBlind hides or restores the difficulty, topics and hints of slugs.
+func (s *Store) SetBlind(ctx context.Context, slugs []string, blind bool) error {
+	for _, slug := range slugs {
+		if _, err := s.db.ExecContext(ctx, `UPDATE problems SET blind=? WHERE slug=?`, boolToInt(blind), slug); err != nil {
+			return fmt.Errorf("update problem visibility: %w", err)
+		}
+	}
+	return nil
+}
+
+// SubmissionsSince returns slug's submissions made at or after since, oldest
+// first.
+func (s *Store) SubmissionsSince(ctx context.Context, slug string, since time.Time) ([]Submission, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT `+submissionColumns+` FROM submissions WHERE slug=? AND created_at >= ? ORDER BY created_at ASC, id ASC`, slug, since.UTC().Format("2006-01-02 15:04:05"))
+	if err != nil {
+		return nil, fmt.Errorf("list submissions: %w", err)
+	}
+	defer rows.Close()
+	out := make([]Submission, 0)
+	for rows.Next() {
+		sub, err := scanSubmission(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, sub)
+	}
+	return out, rows.Err()
+}
+
+// TestRunsSince counts slug's local test runs at or after since and reports
+// whether the latest of them passed.
+func (s *Store) TestRunsSince(ctx context.Context, slug string, since time.Time) (int, bool, error) {
+	ts := since.UTC().Format("2006-01-02 15:04:05")
+	var n int
+	var lastPassed sql.NullInt64
+	err := s.db.QueryRowContext(ctx, `
+SELECT COUNT(*), (SELECT passed FROM test_runs WHERE slug=? AND created_at >= ? ORDER BY id DESC LIMIT 1)
+FROM test_runs WHERE slug=? AND created_at >= ?`, slug, ts, slug, ts).Scan(&n, &lastPassed)
+	if err != nil {
+		return 0, false, fmt.Errorf("count test runs: %w", err)
+	}
+	return n, lastPassed.Valid && lastPassed.Int64 == 1, nil
+}
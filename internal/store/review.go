@@ -0,0 +1,170 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Review is the spaced-repetition state of a solved problem. DueDate is a
+// local YYYY-MM-DD day; BaselineSec is the original solve time that review
+// attempts are measured against.
+type Review struct {
+	Slug           string
+	Repetitions    int
+	IntervalDays   int
+	Ease           float64
+	DueDate        string
+	LastGrade      int
+	LastReviewedAt string
+	BaselineSec    int
+}
+
+// ReviewSession is one re-solve of a review problem, open until finished.
+// StartedAt is when the review copy was first opened or tested, or when the
+// session was prepared if it never was.
+type ReviewSession struct {
+	ID          int64
+	Slug        string
+	StartedAt   time.Time
+	HintsBefore int
+	Failures    int
+}
+
+// EnrollReview adds slug to the review queue, first due on due. Problems
+// already in the queue keep their schedule.
+func (s *Store) EnrollReview(ctx context.Context, slug string, due time.Time) error {
+	_, err := s.db.ExecContext(ctx, `INSERT INTO reviews(slug, due_date) VALUES(?, ?) ON CONFLICT(slug) DO NOTHING`, slug, due.Format("2006-01-02"))
+	if err != nil {
+		return fmt.Errorf("enroll review: %w", err)
+	}
+	return nil
+}
+
+// SetReviewDue queues slug for review on due, enrolling it if needed.
+func (s *Store) SetReviewDue(ctx context.Context, slug string, due time.Time) error {
+	_, err := s.db.ExecContext(ctx, `INSERT INTO reviews(slug, due_date) VALUES(?, ?) ON CONFLICT(slug) DO UPDATE SET due_date=excluded.due_date`, slug, due.Format("2006-01-02"))
+	if err != nil {
+		return fmt.Errorf("schedule review: %w", err)
+	}
+	return nil
+}
+
+const reviewColumns = `slug, repetitions, interval_days, ease, due_date, last_grade, last_reviewed_at, baseline_sec`
+
+func scanReview(row rowScanner) (Review, error) {
+	var r Review
+	err := row.Scan(&r.Slug, &r.Repetitions, &r.IntervalDays, &r.Ease, &r.DueDate, &r.LastGrade, &r.LastReviewedAt, &r.BaselineSec)
+	return r, err
+}
+
+func (s *Store) GetReview(ctx context.Context, slug string) (Review, error) {
+	return scanReview(s.db.QueryRowContext(ctx, `SELECT `+reviewColumns+` FROM reviews WHERE slug=?`, slug))
+}
+
+// ListReviews returns the review queue ordered by due date. With day set
+// (YYYY-MM-DD) only reviews due on or before that day are returned.
+func (s *Store) ListReviews(ctx context.Context, day string) ([]Review, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT `+reviewColumns+` FROM reviews WHERE (? = '' OR due_date <= ?) ORDER BY due_date ASC, slug ASC`, day, day)
+	if err != nil {
+		return nil, fmt.Errorf("list reviews: %w", err)
+	}
+	defer rows.Close()
+	out := make([]Review, 0)
+	for rows.Next() {
+		r, err := scanReview(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, r)
+	}
+	return out, rows.Err()
+}
+
+// UpdateReview stores the schedule computed after a graded review.
+func (s *Store) UpdateReview(ctx context.Context, r Review) error {
+	_, err := s.db.ExecContext(ctx, `
+UPDATE reviews SET repetitions=?, interval_days=?, ease=?, due_date=?, last_grade=?, last_reviewed_at=CURRENT_TIMESTAMP, baseline_sec=?
+WHERE slug=?`, r.Repetitions, r.IntervalDays, r.Ease, r.DueDate, r.LastGrade, r.BaselineSec, r.Slug)
+	if err != nil {
+		return fmt.Errorf("update review: %w", err)
+	}
+	return nil
+}
+
+// StartReviewSession opens a re-solve of slug, discarding any unfinished
+// one. The first session fixes the review baseline at the problem's current
+// time_spent_sec.
+func (s *Store) StartReviewSession(ctx context.Context, slug string) error {
+	hints, err := s.HintsUsed(ctx, slug)
+	if err != nil {
+		return err
+	}
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM review_sessions WHERE slug=? AND finished_unix IS NULL`, slug); err != nil {
+		return fmt.Errorf("reset review session: %w", err)
+	}
+	if _, err := s.db.ExecContext(ctx, `INSERT INTO review_sessions(slug, started_unix, hints_before) VALUES(?, ?, ?)`, slug, time.Now().Unix(), hints); err != nil {
+		return fmt.Errorf("start review session: %w", err)
+	}
+	_, err = s.db.ExecContext(ctx, `
+UPDATE reviews SET baseline_sec=(SELECT time_spent_sec FROM problems WHERE problems.slug=reviews.slug)
+WHERE slug=? AND baseline_sec=0`, slug)
+	if err != nil {
+		return fmt.Errorf("set review baseline: %w", err)
+	}
+	_, _ = s.db.ExecContext(ctx, `INSERT INTO activity(slug, kind, payload) VALUES(?, 'review_start', '')`, slug)
+	return nil
+}
+
+// OpenReviewSession returns the unfinished review session of slug, or
+// sql.ErrNoRows when the problem is not being reviewed.
+func (s *Store) OpenReviewSession(ctx context.Context, slug string) (ReviewSession, error) {
+	var rs ReviewSession
+	var started, active int64
+	err := s.db.QueryRowContext(ctx, `SELECT id, slug, started_unix, active_unix, hints_before, failures FROM review_sessions WHERE slug=? AND finished_unix IS NULL ORDER BY id DESC LIMIT 1`, slug).
+		Scan(&rs.ID, &rs.Slug, &started, &active, &rs.HintsBefore, &rs.Failures)
+	if active > 0 {
+		started = active
+	}
+	rs.StartedAt = time.Unix(started, 0)
+	return rs, err
+}
+
+// ActivateReviewSession starts the clock of the open review session of slug
+// the first time its review copy is used, so reviews prepared together are
+// each timed from when work on them began.
+func (s *Store) ActivateReviewSession(ctx context.Context, slug string) error {
+	_, err := s.db.ExecContext(ctx, `UPDATE review_sessions SET active_unix=? WHERE slug=? AND finished_unix IS NULL AND active_unix=0`, time.Now().Unix(), slug)
+	if err != nil {
+		return fmt.Errorf("activate review session: %w", err)
+	}
+	return nil
+}
+
+// AddReviewFailure counts a failing test run or rejected submission against
+// the open review session of slug, if any.
+func (s *Store) AddReviewFailure(ctx context.Context, slug string) error {
+	_, err := s.db.ExecContext(ctx, `UPDATE review_sessions SET failures=failures+1 WHERE slug=? AND finished_unix IS NULL`, slug)
+	if err != nil {
+		return fmt.Errorf("record review failure: %w", err)
+	}
+	return nil
+}
+
+// FinishReviewSession closes session id with its grade and duration.
+func (s *Store) FinishReviewSession(ctx context.Context, id int64, grade, elapsedSec int) error {
+	var slug string
+	if err := s.db.QueryRowContext(ctx, `SELECT slug FROM review_sessions WHERE id=?`, id).Scan(&slug); err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("review session %d not found", id)
+		}
+		return err
+	}
+	_, err := s.db.ExecContext(ctx, `UPDATE review_sessions SET finished_unix=?, grade=?, elapsed_sec=? WHERE id=?`, time.Now().Unix(), grade, elapsedSec, id)
+	if err != nil {
+		return fmt.Errorf("finish review session: %w", err)
+	}
+	_, _ = s.db.ExecContext(ctx, `INSERT INTO activity(slug, kind, payload) VALUES(?, 'review', ?)`, slug, fmt.Sprintf("grade=%d elapsed=%d", grade, elapsedSec))
+	return nil
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"time"
 
 	_ "modernc.org/sqlite"
@@ -25,18 +27,37 @@ type Problem struct {
 	Topics          []string
 	StatementHTML   string
 	ExampleTests    string
+	ExampleOutputs  []string
 	CodeStub        string
+	CodeStubs       map[string]string
 	Status          string
 	TimeSpentSec    int
 	LastSubmit      string
 	Runtime         string
 	Memory          string
 	LastFetchedUnix int64
+	Hints           []string
+	Similar         []SimilarProblem
+	AcRate          float64
+	Likes           int
+	Dislikes        int
+}
+
+type SimilarProblem struct {
+	Slug       string `json:"slug"`
+	Title      string `json:"title"`
+	Difficulty string `json:"difficulty"`
 }
 
 type ProblemRow struct {
 	Problem
 	UpdatedAt string
+	// Blind hides difficulty, topics and hints from rendered files while the
+	// problem is part of a running mock interview.
+	Blind bool
+	// Lang is the language slug the problem was last prepared in; commands
+	// without --lang default to it.
+	Lang string
 }
 
 type Stats struct {
@@ -49,6 +70,85 @@ type Stats struct {
 	RecentActivity  []Activity
 	SolvedLast7Days int
 	SolvedPrev7Days int
+	DailySlug       string
+	DailyDone       bool
+	DailyStreak     int
+}
+
+type CatalogEntry struct {
+	FrontendID string
+	Slug       string
+	Title      string
+	Difficulty string
+	PaidOnly   bool
+	Tags       []string
+	AcRate     float64
+}
+
+// CatalogFilter narrows RandomCatalog. Tag is a tag slug such as
+// "dynamic-programming"; Status matches the local problem status, with
+// "todo" also covering problems that were never fetched.
+type CatalogFilter struct {
+	Difficulty string
+	Tag        string
+	Status     string
+	// CachedOnly limits picks to problems whose statement is cached, for
+	// preparing workspaces offline.
+	CachedOnly bool
+	// Unseen limits picks to problems that were never prepared locally.
+	Unseen bool
+}
+
+type Daily struct {
+	Date        string
+	Slug        string
+	CompletedAt string
+}
+
+type TestRun struct {
+	ID          int64
+	Slug        string
+	Passed      bool
+	FailedCount int
+	Output      string
+	CreatedAt   string
+	Cases       []TestCase
+}
+
+type Submission struct {
+	ID                int64
+	Slug              string
+	Status            string
+	Runtime           string
+	Memory            string
+	RuntimePercentile float64
+	MemoryPercentile  float64
+	TotalCorrect      int
+	TotalTestcases    int
+	LastTestcase      string
+	ExpectedOutput    string
+	CodeOutput        string
+	StdOutput         string
+	CompileError      string
+	RuntimeError      string
+	Lang              string
+	Code              string
+	CreatedAt         string
+	// SentAt is when the submission was sent to the judge; zero for
+	// submissions recorded before it was tracked.
+	SentAt time.Time
+}
+
+type TestCase struct {
+	Name      string
+	Status    string
+	Input     string
+	Expected  string
+	Actual    string
+	Stdout    string
+	ElapsedMS float64
+	Error     string
+	Passed    bool
 }
 
 type Activity struct {
@@ -131,6 +231,101 @@ CREATE TABLE IF NOT EXISTS test_runs (
   created_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP
 );
 
+CREATE TABLE IF NOT EXISTS test_cases (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  run_id INTEGER NOT NULL,
+  idx INTEGER NOT NULL,
+  name TEXT NOT NULL DEFAULT '',
+  input TEXT NOT NULL DEFAULT '',
+  expected TEXT NOT NULL DEFAULT '',
+  actual TEXT NOT NULL DEFAULT '',
+  stdout TEXT NOT NULL DEFAULT '',
+  elapsed_ms REAL NOT NULL DEFAULT 0,
+  error TEXT NOT NULL DEFAULT '',
+  passed INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS submissions (
+  id INTEGER PRIMARY KEY,
+  slug TEXT NOT NULL,
+  status TEXT NOT NULL DEFAULT '',
+  runtime TEXT NOT NULL DEFAULT '',
+  memory TEXT NOT NULL DEFAULT '',
+  runtime_percentile REAL NOT NULL DEFAULT 0,
+  memory_percentile REAL NOT NULL DEFAULT 0,
+  total_correct INTEGER NOT NULL DEFAULT 0,
+  total_testcases INTEGER NOT NULL DEFAULT 0,
+  last_testcase TEXT NOT NULL DEFAULT '',
+  expected_output TEXT NOT NULL DEFAULT '',
+  code_output TEXT NOT NULL DEFAULT '',
+  std_output TEXT NOT NULL DEFAULT '',
+  compile_error TEXT NOT NULL DEFAULT '',
+  runtime_error TEXT NOT NULL DEFAULT '',
+  created_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS catalog (
+  slug TEXT PRIMARY KEY,
+  frontend_id TEXT NOT NULL DEFAULT '',
+  title TEXT NOT NULL DEFAULT '',
+  difficulty TEXT NOT NULL DEFAULT '',
+  paid_only INTEGER NOT NULL DEFAULT 0,
+  tags_json TEXT NOT NULL DEFAULT '[]',
+  ac_rate REAL NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS dailies (
+  date TEXT PRIMARY KEY,
+  slug TEXT NOT NULL,
+  completed_at TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS reviews (
+  slug TEXT PRIMARY KEY,
+  repetitions INTEGER NOT NULL DEFAULT 0,
+  interval_days INTEGER NOT NULL DEFAULT 0,
+  ease REAL NOT NULL DEFAULT 2.5,
+  due_date TEXT NOT NULL,
+  last_grade INTEGER NOT NULL DEFAULT -1,
+  last_reviewed_at TEXT NOT NULL DEFAULT '',
+  baseline_sec INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS review_sessions (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  slug TEXT NOT NULL,
+  started_unix INTEGER NOT NULL,
+  finished_unix INTEGER,
+  hints_before INTEGER NOT NULL DEFAULT 0,
+  failures INTEGER NOT NULL DEFAULT 0,
+  grade INTEGER,
+  elapsed_sec INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS interviews (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  started_unix INTEGER NOT NULL,
+  duration_min INTEGER NOT NULL,
+  difficulty TEXT NOT NULL DEFAULT '',
+  slugs_json TEXT NOT NULL DEFAULT '[]',
+  finished_unix INTEGER,
+  score INTEGER NOT NULL DEFAULT 0,
+  report TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS contest_runs (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  contest_slug TEXT NOT NULL,
+  title TEXT NOT NULL DEFAULT '',
+  started_unix INTEGER NOT NULL,
+  duration_min INTEGER NOT NULL,
+  problems_json TEXT NOT NULL DEFAULT '[]',
+  finished_unix INTEGER,
+  score INTEGER NOT NULL DEFAULT 0,
+  finish_sec INTEGER NOT NULL DEFAULT 0,
+  penalties INTEGER NOT NULL DEFAULT 0
+);
+
 CREATE TABLE IF NOT EXISTS activity (
   id INTEGER PRIMARY KEY AUTOINCREMENT,
   slug TEXT NOT NULL,
@@ -148,11 +343,81 @@ CREATE TABLE IF NOT EXISTS settings (
 	if err != nil {
 		return fmt.Errorf("migrate schema: %w", err)
 	}
+	columns := []struct{ table, name, def string }{
+		{"problems", "code_stubs_json", "TEXT NOT NULL DEFAULT '{}'"},
+		{"problems", "example_outputs_json", "TEXT NOT NULL DEFAULT '[]'"},
+		{"problems", "hints_json", "TEXT NOT NULL DEFAULT '[]'"},
+		{"problems", "similar_json", "TEXT NOT NULL DEFAULT '[]'"},
+		{"problems", "ac_rate", "REAL NOT NULL DEFAULT 0"},
+		{"problems", "likes", "INTEGER NOT NULL DEFAULT 0"},
+		{"problems", "dislikes", "INTEGER NOT NULL DEFAULT 0"},
+		{"problems", "blind", "INTEGER NOT NULL DEFAULT 0"},
+		{"problems", "lang", "TEXT NOT NULL DEFAULT ''"},
+		{"test_cases", "status", "TEXT NOT NULL DEFAULT ''"},
+		{"submissions", "lang", "TEXT NOT NULL DEFAULT ''"},
+		{"submissions", "code", "TEXT NOT NULL DEFAULT ''"},
+		{"timer_sessions", "exceeded", "INTEGER NOT NULL DEFAULT 0"},
+		{"review_sessions", "active_unix", "INTEGER NOT NULL DEFAULT 0"},
+		{"submissions", "sent_unix", "INTEGER NOT NULL DEFAULT 0"},
+		{"dailies", "ends_unix", "INTEGER NOT NULL DEFAULT 0"},
+	}
+	for _, c := range columns {
+		if err := s.ensureColumn(ctx, c.table, c.name, c.def); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Store) ensureColumn(ctx context.Context, table, column, def string) error {
+	rows, err := s.db.QueryContext(ctx, fmt.Sprintf(`PRAGMA table_info(%s)`, table))
+	if err != nil {
+		return fmt.Errorf("inspect %s: %w", table, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			cid     int
+			name    string
+			typ     string
+			notNull int
+			dflt    sql.NullString
+			pk      int
+		)
+		if err := rows.Scan(&cid, &name, &typ, &notNull, &dflt, &pk); err != nil {
+			return err
+		}
+		if name == column {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if _, err := s.db.ExecContext(ctx, fmt.Sprintf(`ALTER TABLE %s ADD COLUMN %s %s`, table, column, def)); err != nil {
+		return fmt.Errorf("add column %s.%s: %w", table, column, err)
+	}
 	return nil
 }
 
 func (s *Store) UpsertProblem(ctx context.Context, p Problem) error {
 	topics, _ := json.Marshal(p.Topics)
+	stubs, _ := json.Marshal(p.CodeStubs)
+	if p.CodeStubs == nil {
+		stubs = []byte("{}")
+	}
+	outputs, _ := json.Marshal(p.ExampleOutputs)
+	if p.ExampleOutputs == nil {
+		outputs = []byte("[]")
+	}
+	hints, _ := json.Marshal(p.Hints)
+	if p.Hints == nil {
+		hints = []byte("[]")
+	}
+	similar, _ := json.Marshal(p.Similar)
+	if p.Similar == nil {
+		similar = []byte("[]")
+	}
 	if p.Status == "" {
 		p.Status = "todo"
 	}
@@ -160,8 +425,8 @@ func (s *Store) UpsertProblem(ctx context.Context, p Problem) error {
 		p.LastFetchedUnix = time.Now().Unix()
 	}
 	_, err := s.db.ExecContext(ctx, `
-INSERT INTO problems (slug, frontend_id, question_id, title, difficulty, topics_json, statement_html, example_tests, code_stub, status, time_spent_sec, last_submit, runtime, memory, last_fetched_unix, updated_at)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, COALESCE(NULLIF(?, ''), 'todo'), ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+INSERT INTO problems (slug, frontend_id, question_id, title, difficulty, topics_json, statement_html, example_tests, example_outputs_json, code_stub, code_stubs_json, status, time_spent_sec, last_submit, runtime, memory, last_fetched_unix, hints_json, similar_json, ac_rate, likes, dislikes, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, COALESCE(NULLIF(?, ''), 'todo'), ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
 ON CONFLICT(slug) DO UPDATE SET
   frontend_id=excluded.frontend_id,
   question_id=excluded.question_id,
@@ -170,10 +435,17 @@ ON CONFLICT(slug) DO UPDATE SET
   topics_json=excluded.topics_json,
   statement_html=excluded.statement_html,
   example_tests=excluded.example_tests,
+  example_outputs_json=excluded.example_outputs_json,
   code_stub=excluded.code_stub,
+  code_stubs_json=excluded.code_stubs_json,
   last_fetched_unix=excluded.last_fetched_unix,
+  hints_json=excluded.hints_json,
+  similar_json=excluded.similar_json,
+  ac_rate=excluded.ac_rate,
+  likes=excluded.likes,
+  dislikes=excluded.dislikes,
   updated_at=CURRENT_TIMESTAMP
-`, p.Slug, p.FrontendID, p.QuestionID, p.Title, p.Difficulty, string(topics), p.StatementHTML, p.ExampleTests, p.CodeStub, p.Status, p.TimeSpentSec, p.LastSubmit, p.Runtime, p.Memory, p.LastFetchedUnix)
+`, p.Slug, p.FrontendID, p.QuestionID, p.Title, p.Difficulty, string(topics), p.StatementHTML, p.ExampleTests, string(outputs), p.CodeStub, string(stubs), p.Status, p.TimeSpentSec, p.LastSubmit, p.Runtime, p.Memory, p.LastFetchedUnix, string(hints), string(similar), p.AcRate, p.Likes, p.Dislikes)
 	if err != nil {
 		return fmt.Errorf("upsert problem: %w", err)
 	}
@@ -181,21 +453,13 @@ ON CONFLICT(slug) DO UPDATE SET
 }
 
 func (s *Store) GetProblem(ctx context.Context, slug string) (ProblemRow, error) {
-	row := s.db.QueryRowContext(ctx, `
-SELECT slug, frontend_id, question_id, title, difficulty, topics_json, statement_html, example_tests, code_stub, status, time_spent_sec, last_submit, runtime, memory, last_fetched_unix, updated_at
-FROM problems WHERE slug = ?`, slug)
-	var pr ProblemRow
-	var topicsJSON string
-	if err := row.Scan(&pr.Slug, &pr.FrontendID, &pr.QuestionID, &pr.Title, &pr.Difficulty, &topicsJSON, &pr.StatementHTML, &pr.ExampleTests, &pr.CodeStub, &pr.Status, &pr.TimeSpentSec, &pr.LastSubmit, &pr.Runtime, &pr.Memory, &pr.LastFetchedUnix, &pr.UpdatedAt); err != nil {
-		return ProblemRow{}, err
-	}
-	_ = json.Unmarshal([]byte(topicsJSON), &pr.Topics)
-	return pr, nil
+	row := s.db.QueryRowContext(ctx, `SELECT `+problemColumns+` FROM problems WHERE slug = ?`, slug)
+	return scanProblem(row)
 }
 
 func (s *Store) ListProblems(ctx context.Context, difficulty, status, query string) ([]ProblemRow, error) {
 	rows, err := s.db.QueryContext(ctx, `
-SELECT slug, frontend_id, question_id, title, difficulty, topics_json, statement_html, example_tests, code_stub, status, time_spent_sec, last_submit, runtime, memory, last_fetched_unix, updated_at
+SELECT `+problemColumns+`
 FROM problems
 WHERE (? = '' OR difficulty = ?)
   AND (? = '' OR status = ?)
@@ -209,17 +473,45 @@ ORDER BY CAST(frontend_id AS INTEGER) ASC, slug ASC
 
 	out := make([]ProblemRow, 0)
 	for rows.Next() {
-		var pr ProblemRow
-		var topicsJSON string
-		if err := rows.Scan(&pr.Slug, &pr.FrontendID, &pr.QuestionID, &pr.Title, &pr.Difficulty, &topicsJSON, &pr.StatementHTML, &pr.ExampleTests, &pr.CodeStub, &pr.Status, &pr.TimeSpentSec, &pr.LastSubmit, &pr.Runtime, &pr.Memory, &pr.LastFetchedUnix, &pr.UpdatedAt); err != nil {
+		pr, err := scanProblem(rows)
+		if err != nil {
 			return nil, err
 		}
-		_ = json.Unmarshal([]byte(topicsJSON), &pr.Topics)
 		out = append(out, pr)
 	}
 	return out, rows.Err()
 }
 
+const problemColumns = `slug, frontend_id, question_id, title, difficulty, topics_json, statement_html, example_tests, example_outputs_json, code_stub, code_stubs_json, status, time_spent_sec, last_submit, runtime, memory, last_fetched_unix, hints_json, similar_json, ac_rate, likes, dislikes, blind, lang, updated_at`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanProblem(row rowScanner) (ProblemRow, error) {
+	var pr ProblemRow
+	var topicsJSON, outputsJSON, stubsJSON, hintsJSON, similarJSON string
+	var blind int
+	if err := row.Scan(&pr.Slug, &pr.FrontendID, &pr.QuestionID, &pr.Title, &pr.Difficulty, &topicsJSON, &pr.StatementHTML, &pr.ExampleTests, &outputsJSON, &pr.CodeStub, &stubsJSON, &pr.Status, &pr.TimeSpentSec, &pr.LastSubmit, &pr.Runtime, &pr.Memory, &pr.LastFetchedUnix, &hintsJSON, &similarJSON, &pr.AcRate, &pr.Likes, &pr.Dislikes, &blind, &pr.Lang, &pr.UpdatedAt); err != nil {
+		return ProblemRow{}, err
+	}
+	pr.Blind = blind != 0
+	_ = json.Unmarshal([]byte(hintsJSON), &pr.Hints)
+	_ = json.Unmarshal([]byte(similarJSON), &pr.Similar)
+	_ = json.Unmarshal([]byte(topicsJSON), &pr.Topics)
+	_ = json.Unmarshal([]byte(outputsJSON), &pr.ExampleOutputs)
+	_ = json.Unmarshal([]byte(stubsJSON), &pr.CodeStubs)
+	if pr.CodeStub != "" {
+		if pr.CodeStubs == nil {
+			pr.CodeStubs = map[string]string{}
+		}
+		if _, ok := pr.CodeStubs["python3"]; !ok {
+			pr.CodeStubs["python3"] = pr.CodeStub
+		}
+	}
+	return pr, nil
+}
+
 func (s *Store) SetProblemStatus(ctx context.Context, slug, status string) error {
 	_, err := s.db.ExecContext(ctx, `UPDATE problems SET status=?, updated_at=CURRENT_TIMESTAMP WHERE slug=?`, status, slug)
 	if err != nil {
@@ -227,10 +519,57 @@ func (s *Store) SetProblemStatus(ctx context.Context, slug, status string) error
 	}
 	if status == "solved" {
 		_, _ = s.db.ExecContext(ctx, `INSERT INTO activity(slug, kind, payload) VALUES(?, 'solved', '')`, slug)
+		_ = s.EnrollReview(ctx, slug, time.Now().AddDate(0, 0, 1))
+	}
+	return nil
+}
+
+// SetProblemLang remembers the language slug was prepared in.
+func (s *Store) SetProblemLang(ctx context.Context, slug, lang string) error {
+	if _, err := s.db.ExecContext(ctx, `UPDATE problems SET lang=? WHERE slug=?`, lang, slug); err != nil {
+		return fmt.Errorf("set problem language: %w", err)
 	}
 	return nil
 }
 
+// MarkProgress records solved/attempted state imported from the user's
+// account. Problems never fetched locally get a placeholder row; a solved
+// problem is never downgraded.
+func (s *Store) MarkProgress(ctx context.Context, p Problem, status string) error {
+	_, err := s.db.ExecContext(ctx, `
+INSERT INTO problems (slug, frontend_id, question_id, title, difficulty, status)
+VALUES (?, ?, '', ?, ?, ?)
+ON CONFLICT(slug) DO UPDATE SET
+  status=CASE
+    WHEN problems.status='solved' OR excluded.status='solved' THEN 'solved'
+    WHEN problems.status='todo' THEN excluded.status
+    ELSE problems.status
+  END,
+  updated_at=CURRENT_TIMESTAMP
+`, p.Slug, p.FrontendID, p.Title, p.Difficulty, status)
+	if err != nil {
+		return fmt.Errorf("mark progress: %w", err)
+	}
+	return nil
+}
+
+// AddActivityAt backfills an activity entry with its original timestamp,
+// skipping entries that were already imported.
+func (s *Store) AddActivityAt(ctx context.Context, slug, kind, payload string, at time.Time) (bool, error) {
+	ts := at.UTC().Format("2006-01-02 15:04:05")
+	var n int
+	if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM activity WHERE slug=? AND kind=? AND created_at=?`, slug, kind, ts).Scan(&n); err != nil {
+		return false, err
+	}
+	if n > 0 {
+		return false, nil
+	}
+	if _, err := s.db.ExecContext(ctx, `INSERT INTO activity(slug, kind, payload, created_at) VALUES(?, ?, ?, ?)`, slug, kind, payload, ts); err != nil {
+		return false, fmt.Errorf("add activity: %w", err)
+	}
+	return true, nil
+}
+
 func (s *Store) AddNote(ctx context.Context, slug, note string, tags []string) error {
 	t, _ := json.Marshal(tags)
 	_, err := s.db.ExecContext(ctx, `INSERT INTO notes(slug, note, tags_json) VALUES(?, ?, ?)`, slug, note, string(t))
@@ -241,6 +580,23 @@ func (s *Store) AddNote(ctx context.Context, slug, note string, tags []string) e
 	return nil
 }
 
+// HintsUsed returns how many hints of slug have been revealed.
+func (s *Store) HintsUsed(ctx context.Context, slug string) (int, error) {
+	var n int
+	if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM activity WHERE slug=? AND kind='hint'`, slug).Scan(&n); err != nil {
+		return 0, fmt.Errorf("count hints: %w", err)
+	}
+	return n, nil
+}
+
+// RecordHint logs that hint n (1-based) of slug was revealed.
+func (s *Store) RecordHint(ctx context.Context, slug string, n int) error {
+	if _, err := s.db.ExecContext(ctx, `INSERT INTO activity(slug, kind, payload) VALUES(?, 'hint', ?)`, slug, strconv.Itoa(n)); err != nil {
+		return fmt.Errorf("record hint: %w", err)
+	}
+	return nil
+}
+
 func (s *Store) StartTimer(ctx context.Context, slug string, targetMinutes int, manual bool) error {
 	_, err := s.db.ExecContext(ctx, `INSERT INTO timer_sessions(slug, start_unix, target_minutes, manual) VALUES(?, ?, ?, ?)`, slug, time.Now().Unix(), targetMinutes, boolToInt(manual))
 	if err != nil {
@@ -251,10 +607,11 @@ func (s *Store) StartTimer(ctx context.Context, slug string, targetMinutes int,
 }
 
 func (s *Store) StopTimer(ctx context.Context, slug string) (int, error) {
-	row := s.db.QueryRowContext(ctx, `SELECT id, start_unix FROM timer_sessions WHERE slug=? AND end_unix IS NULL ORDER BY id DESC LIMIT 1`, slug)
+	row := s.db.QueryRowContext(ctx, `SELECT id, start_unix, target_minutes FROM timer_sessions WHERE slug=? AND end_unix IS NULL ORDER BY id DESC LIMIT 1`, slug)
 	var id int64
 	var start int64
-	if err := row.Scan(&id, &start); err != nil {
+	var target int
+	if err := row.Scan(&id, &start, &target); err != nil {
 		if err == sql.ErrNoRows {
 			return 0, nil
 		}
@@ -262,7 +619,8 @@ func (s *Store) StopTimer(ctx context.Context, slug string) (int, error) {
 	}
 	now := time.Now().Unix()
 	dur := int(now - start)
-	_, err := s.db.ExecContext(ctx, `UPDATE timer_sessions SET end_unix=? WHERE id=?`, now, id)
+	exceeded := target > 0 && dur > target*60
+	_, err := s.db.ExecContext(ctx, `UPDATE timer_sessions SET end_unix=?, exceeded=MAX(exceeded, ?) WHERE id=?`, now, boolToInt(exceeded), id)
 	if err != nil {
 		return 0, fmt.Errorf("stop timer: %w", err)
 	}
@@ -271,6 +629,47 @@ func (s *Store) StopTimer(ctx context.Context, slug string) (int, error) {
 	return dur, nil
 }
 
+// TimerSession is a running solve timer.
+type TimerSession struct {
+	ID            int64
+	Slug          string
+	StartedAt     time.Time
+	TargetMinutes int
+	Exceeded      bool
+}
+
+// Deadline is when the timer's target runs out.
+func (t TimerSession) Deadline() time.Time {
+	return t.StartedAt.Add(time.Duration(t.TargetMinutes) * time.Minute)
+}
+
+// ActiveTimer returns slug's running timer, or sql.ErrNoRows when none is
+// running.
+func (s *Store) ActiveTimer(ctx context.Context, slug string) (TimerSession, error) {
+	t := TimerSession{Slug: slug}
+	var start int64
+	var exceeded int
+	err := s.db.QueryRowContext(ctx, `SELECT id, start_unix, target_minutes, exceeded FROM timer_sessions WHERE slug=? AND end_unix IS NULL ORDER BY id DESC LIMIT 1`, slug).Scan(&t.ID, &start, &t.TargetMinutes, &exceeded)
+	if err != nil {
+		return TimerSession{}, err
+	}
+	t.StartedAt = time.Unix(start, 0)
+	t.Exceeded = exceeded == 1
+	return t, nil
+}
+
+// MarkTimerExceeded records that the timer ran past its target.
+func (s *Store) MarkTimerExceeded(ctx context.Context, id int64) error {
+	res, err := s.db.ExecContext(ctx, `UPDATE timer_sessions SET exceeded=1 WHERE id=? AND exceeded=0`, id)
+	if err != nil {
+		return fmt.Errorf("mark timer exceeded: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n > 0 {
+		_, _ = s.db.ExecContext(ctx, `INSERT INTO activity(slug, kind, payload) SELECT slug, 'timer_exceeded', target_minutes FROM timer_sessions WHERE id=?`, id)
+	}
+	return nil
+}
+
 func (s *Store) AddManualTime(ctx context.Context, slug string, minutes int) error {
 	sec := minutes * 60
 	_, err := s.db.ExecContext(ctx, `UPDATE problems SET time_spent_sec=time_spent_sec+?, updated_at=CURRENT_TIMESTAMP WHERE slug=?`, sec, slug)
@@ -295,15 +694,165 @@ func (s *Store) CurrentProblem(ctx context.Context) (string, error) {
 	return slug, nil
 }
 
-func (s *Store) SaveTestRun(ctx context.Context, slug string, passed bool, failed int, output string) error {
-	_, err := s.db.ExecContext(ctx, `INSERT INTO test_runs(slug, passed, failed_count, output) VALUES(?, ?, ?, ?)`, slug, boolToInt(passed), failed, output)
+func (s *Store) SetActivePlan(ctx context.Context, id string) error {
+	_, err := s.db.ExecContext(ctx, `INSERT INTO settings(key, value) VALUES('active_plan', ?) ON CONFLICT(key) DO UPDATE SET value=excluded.value`, id)
+	return err
+}
+
+// ActivePlan returns the id of the study plan being worked through, or ""
+// when none was started.
+func (s *Store) ActivePlan(ctx context.Context) (string, error) {
+	var id string
+	err := s.db.QueryRowContext(ctx, `SELECT value FROM settings WHERE key='active_plan'`).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return id, err
+}
+
+// ProblemStatuses maps every stored problem to its status.
+func (s *Store) ProblemStatuses(ctx context.Context) (map[string]string, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT slug, status FROM problems`)
+	if err != nil {
+		return nil, fmt.Errorf("problem statuses: %w", err)
+	}
+	defer rows.Close()
+	out := map[string]string{}
+	for rows.Next() {
+		var slug, status string
+		if err := rows.Scan(&slug, &status); err != nil {
+			return nil, err
+		}
+		out[slug] = status
+	}
+	return out, rows.Err()
+}
+
+func (s *Store) SaveTestRun(ctx context.Context, slug string, passed bool, failed int, output string, cases []TestCase) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	res, err := tx.ExecContext(ctx, `INSERT INTO test_runs(slug, passed, failed_count, output) VALUES(?, ?, ?, ?)`, slug, boolToInt(passed), failed, output)
+	if err != nil {
+		return err
+	}
+	runID, err := res.LastInsertId()
 	if err != nil {
 		return err
 	}
+	for i, c := range cases {
+		_, err := tx.ExecContext(ctx, `INSERT INTO test_cases(run_id, idx, name, status, input, expected, actual, stdout, elapsed_ms, error, passed) VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			runID, i, c.Name, c.Status, c.Input, c.Expected, c.Actual, c.Stdout, c.ElapsedMS, c.Error, boolToInt(c.Passed))
+		if err != nil {
+			return fmt.Errorf("save test case: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
 	_, _ = s.db.ExecContext(ctx, `INSERT INTO activity(slug, kind, payload) VALUES(?, 'test', ?)`, slug, fmt.Sprintf("passed=%t failed=%d", passed, failed))
 	return nil
 }
 
+func (s *Store) LastTestRun(ctx context.Context, slug string) (TestRun, error) {
+	var tr TestRun
+	var passed int
+	row := s.db.QueryRowContext(ctx, `SELECT id, slug, passed, failed_count, output, created_at FROM test_runs WHERE slug=? ORDER BY id DESC LIMIT 1`, slug)
+	if err := row.Scan(&tr.ID, &tr.Slug, &passed, &tr.FailedCount, &tr.Output, &tr.CreatedAt); err != nil {
+		return TestRun{}, err
+	}
+	tr.Passed = passed == 1
+	rows, err := s.db.QueryContext(ctx, `SELECT name, status, input, expected, actual, stdout, elapsed_ms, error, passed FROM test_cases WHERE run_id=? ORDER BY idx ASC`, tr.ID)
+	if err != nil {
+		return TestRun{}, fmt.Errorf("load test cases: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var c TestCase
+		var ok int
+		if err := rows.Scan(&c.Name, &c.Status, &c.Input, &c.Expected, &c.Actual, &c.Stdout, &c.ElapsedMS, &c.Error, &ok); err != nil {
+			return TestRun{}, err
+		}
+		c.Passed = ok == 1
+		tr.Cases = append(tr.Cases, c)
+	}
+	return tr, rows.Err()
+}
+
+// SaveSubmission records the full judge verdict for a submission and updates
+// the problem's last result.
+func (s *Store) SaveSubmission(ctx context.Context, sub Submission) error {
+	_, err := s.db.ExecContext(ctx, `
+INSERT INTO submissions(id, slug, status, runtime, memory, runtime_percentile, memory_percentile, total_correct, total_testcases, last_testcase, expected_output, code_output, std_output, compile_error, runtime_error, lang, code, sent_unix)
+VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(id) DO UPDATE SET
+  status=excluded.status,
+  runtime=excluded.runtime,
+  memory=excluded.memory,
+  runtime_percentile=excluded.runtime_percentile,
+  memory_percentile=excluded.memory_percentile,
+  total_correct=excluded.total_correct,
+  total_testcases=excluded.total_testcases,
+  last_testcase=excluded.last_testcase,
+  expected_output=excluded.expected_output,
+  code_output=excluded.code_output,
+  std_output=excluded.std_output,
+  compile_error=excluded.compile_error,
+  runtime_error=excluded.runtime_error,
+  lang=excluded.lang,
+  code=excluded.code,
+  sent_unix=CASE WHEN excluded.sent_unix > 0 THEN excluded.sent_unix ELSE submissions.sent_unix END
+`, sub.ID, sub.Slug, sub.Status, sub.Runtime, sub.Memory, sub.RuntimePercentile, sub.MemoryPercentile, sub.TotalCorrect, sub.TotalTestcases, sub.LastTestcase, sub.ExpectedOutput, sub.CodeOutput, sub.StdOutput, sub.CompileError, sub.RuntimeError, sub.Lang, sub.Code, unixOrZero(sub.SentAt))
+	if err != nil {
+		return fmt.Errorf("save submission: %w", err)
+	}
+	return s.SaveSubmissionResult(ctx, sub.Slug, sub.Status, sub.Runtime, sub.Memory)
+}
+
+const submissionColumns = `id, slug, status, runtime, memory, runtime_percentile, memory_percentile, total_correct, total_testcases, last_testcase, expected_output, code_output, std_output, compile_error, runtime_error, lang, code, created_at, sent_unix`
+
+func scanSubmission(row rowScanner) (Submission, error) {
+	var sub Submission
+	var sent int64
+	err := row.Scan(&sub.ID, &sub.Slug, &sub.Status, &sub.Runtime, &sub.Memory, &sub.RuntimePercentile, &sub.MemoryPercentile, &sub.TotalCorrect, &sub.TotalTestcases, &sub.LastTestcase, &sub.ExpectedOutput, &sub.CodeOutput, &sub.StdOutput, &sub.CompileError, &sub.RuntimeError, &sub.Lang, &sub.Code, &sub.CreatedAt, &sent)
+	if sent > 0 {
+		sub.SentAt = time.Unix(sent, 0)
+	}
+	return sub, err
+}
+
+// ListSubmissions returns submissions newest first, for one problem or for
+// all problems when slug is empty.
+func (s *Store) ListSubmissions(ctx context.Context, slug string) ([]Submission, error) {
+	q := `SELECT ` + submissionColumns + ` FROM submissions`
+	args := []any{}
+	if slug != "" {
+		q += ` WHERE slug=?`
+		args = append(args, slug)
+	}
+	q += ` ORDER BY created_at DESC, id DESC`
+	rows, err := s.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := make([]Submission, 0)
+	for rows.Next() {
+		sub, err := scanSubmission(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, sub)
+	}
+	return out, rows.Err()
+}
+
+func (s *Store) GetSubmission(ctx context.Context, id int64) (Submission, error) {
+	return scanSubmission(s.db.QueryRowContext(ctx, `SELECT `+submissionColumns+` FROM submissions WHERE id=?`, id))
+}
+
 func (s *Store) SaveSubmissionResult(ctx context.Context, slug, status, runtime, memory string) error {
 	_, err := s.db.ExecContext(ctx, `UPDATE problems SET last_submit=?, runtime=?, memory=?, updated_at=CURRENT_TIMESTAMP WHERE slug=?`, status, runtime, memory, slug)
 	if err != nil {
@@ -312,10 +861,198 @@ func (s *Store) SaveSubmissionResult(ctx context.Context, slug, status, runtime,
 	_, _ = s.db.ExecContext(ctx, `INSERT INTO activity(slug, kind, payload) VALUES(?, 'submit', ?)`, slug, status)
 	if status == "Accepted" {
 		_, _ = s.db.ExecContext(ctx, `UPDATE problems SET status='solved' WHERE slug=?`, slug)
+		_ = s.EnrollReview(ctx, slug, time.Now().AddDate(0, 0, 1))
+		if today, err := s.dailyToday(ctx); err == nil && today != "" {
+			_, _ = s.db.ExecContext(ctx, `UPDATE dailies SET completed_at=CURRENT_TIMESTAMP WHERE slug=? AND date=? AND completed_at=''`, slug, today)
+		}
 	}
 	return nil
 }
 
+// ReplaceCatalog swaps in a freshly downloaded problem catalog and stamps the
+// refresh time.
+func (s *Store) ReplaceCatalog(ctx context.Context, entries []CatalogEntry) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.ExecContext(ctx, `DELETE FROM catalog`); err != nil {
+		return fmt.Errorf("clear catalog: %w", err)
+	}
+	for _, e := range entries {
+		tags, _ := json.Marshal(e.Tags)
+		if e.Tags == nil {
+			tags = []byte("[]")
+		}
+		_, err := tx.ExecContext(ctx, `INSERT OR REPLACE INTO catalog(slug, frontend_id, title, difficulty, paid_only, tags_json, ac_rate) VALUES(?, ?, ?, ?, ?, ?, ?)`,
+			e.Slug, e.FrontendID, e.Title, e.Difficulty, boolToInt(e.PaidOnly), string(tags), e.AcRate)
+		if err != nil {
+			return fmt.Errorf("save catalog entry: %w", err)
+		}
+	}
+	if _, err := tx.ExecContext(ctx, `INSERT INTO settings(key, value) VALUES('catalog_refreshed_unix', ?) ON CONFLICT(key) DO UPDATE SET value=excluded.value`, fmt.Sprintf("%d", time.Now().Unix())); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// CatalogInfo reports when the catalog was last refreshed (zero if never) and
+// how many problems it holds.
+func (s *Store) CatalogInfo(ctx context.Context) (time.Time, int, error) {
+	var n int
+	if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM catalog`).Scan(&n); err != nil {
+		return time.Time{}, 0, err
+	}
+	var raw string
+	err := s.db.QueryRowContext(ctx, `SELECT value FROM settings WHERE key='catalog_refreshed_unix'`).Scan(&raw)
+	if errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, n, nil
+	}
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	unix, _ := strconv.ParseInt(raw, 10, 64)
+	return time.Unix(unix, 0), n, nil
+}
+
+// RandomCatalog picks up to n random free problems from the catalog.
+func (s *Store) RandomCatalog(ctx context.Context, f CatalogFilter, n int) ([]CatalogEntry, error) {
+	q := `
+SELECT c.frontend_id, c.slug, c.title, c.difficulty, c.paid_only, c.tags_json, c.ac_rate
+FROM catalog c
+LEFT JOIN problems p ON p.slug = c.slug
+WHERE c.paid_only = 0`
+	args := []any{}
+	if f.Difficulty != "" {
+		q += ` AND lower(c.difficulty) = lower(?)`
+		args = append(args, f.Difficulty)
+	}
+	if f.Tag != "" {
+		q += ` AND EXISTS (SELECT 1 FROM json_each(c.tags_json) WHERE lower(replace(value, ' ', '-')) = ?)`
+		args = append(args, f.Tag)
+	}
+	if f.Status != "" {
+		q += ` AND COALESCE(p.status, 'todo') = ?`
+		args = append(args, f.Status)
+	}
+	if f.CachedOnly {
+		q += ` AND COALESCE(p.statement_html, '') != ''`
+	}
+	if f.Unseen {
+		q += ` AND p.slug IS NULL`
+	}
+	q += ` ORDER BY RANDOM() LIMIT ?`
+	args = append(args, n)
+	rows, err := s.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := make([]CatalogEntry, 0, n)
+	for rows.Next() {
+		var e CatalogEntry
+		var paid int
+		var tags string
+		if err := rows.Scan(&e.FrontendID, &e.Slug, &e.Title, &e.Difficulty, &paid, &tags, &e.AcRate); err != nil {
+			return nil, err
+		}
+		e.PaidOnly = paid == 1
+		_ = json.Unmarshal([]byte(tags), &e.Tags)
+		out = append(out, e)
+	}
+	return out, rows.Err()
+}
+
+// RecordDaily remembers slug as the daily challenge for the site's date
+// (YYYY-MM-DD), which lasts until ends.
+func (s *Store) RecordDaily(ctx context.Context, date, slug string, ends time.Time) error {
+	res, err := s.db.ExecContext(ctx, `INSERT INTO dailies(date, slug, ends_unix) VALUES(?, ?, ?) ON CONFLICT(date) DO NOTHING`, date, slug, unixOrZero(ends))
+	if err != nil {
+		return fmt.Errorf("record daily: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n > 0 {
+		_, _ = s.db.ExecContext(ctx, `INSERT INTO activity(slug, kind, payload) VALUES(?, 'daily', ?)`, slug, date)
+	}
+	return nil
+}
+
+// TodayDaily returns today's recorded daily challenge, or sql.ErrNoRows when
+// `leet daily` has not been run today.
+func (s *Store) TodayDaily(ctx context.Context) (Daily, error) {
+	var d Daily
+	today, err := s.dailyToday(ctx)
+	if err != nil {
+		return d, err
+	}
+	if today == "" {
+		return d, sql.ErrNoRows
+	}
+	err = s.db.QueryRowContext(ctx, `SELECT date, slug, completed_at FROM dailies WHERE date=?`, today).Scan(&d.Date, &d.Slug, &d.CompletedAt)
+	return d, err
+}
+
+// dailyToday works out the site's current day from the latest recorded daily
+// and when it ends, so days follow the site rather than the local clock. It
+// returns "" before the first `leet daily`.
+func (s *Store) dailyToday(ctx context.Context) (string, error) {
+	var date string
+	var ends int64
+	err := s.db.QueryRowContext(ctx, `SELECT date, ends_unix FROM dailies ORDER BY date DESC LIMIT 1`).Scan(&date, &ends)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	day, err := time.Parse("2006-01-02", date)
+	if err != nil || ends == 0 {
+		return time.Now().UTC().Format("2006-01-02"), nil
+	}
+	if over := time.Since(time.Unix(ends, 0)); over >= 0 {
+		day = day.AddDate(0, 0, 1+int(over/(24*time.Hour)))
+	}
+	return day.Format("2006-01-02"), nil
+}
+
+// DailyStreak counts consecutive completed dailies ending today, or ending
+// yesterday while today's is still open. Days are the site's.
+func (s *Store) DailyStreak(ctx context.Context) (int, error) {
+	today, err := s.dailyToday(ctx)
+	if err != nil || today == "" {
+		return 0, err
+	}
+	day, err := time.Parse("2006-01-02", today)
+	if err != nil {
+		return 0, err
+	}
+	rows, err := s.db.QueryContext(ctx, `SELECT date FROM dailies WHERE completed_at != '' ORDER BY date DESC`)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+	streak := 0
+	for rows.Next() {
+		var date string
+		if err := rows.Scan(&date); err != nil {
+			return 0, err
+		}
+		d, err := time.Parse("2006-01-02", date)
+		if err != nil {
+			continue
+		}
+		if streak == 0 && d.Equal(day.AddDate(0, 0, -1)) {
+			day = d
+		}
+		if !d.Equal(day) {
+			break
+		}
+		streak++
+		day = day.AddDate(0, 0, -1)
+	}
+	return streak, rows.Err()
+}
+
 func (s *Store) Stats(ctx context.Context) (Stats, error) {
 	var st Stats
 	_ = s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM problems`).Scan(&st.TotalProblems)
@@ -329,10 +1066,16 @@ SELECT COUNT(*) FROM (
   FROM problems, json_each(problems.topics_json)
   WHERE status='solved'
 )`).Scan(&st.TopicCoverage)
-	_ = s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM activity WHERE kind='solved' AND datetime(created_at) >= datetime('now','-7 day')`).Scan(&st.SolvedLast7Days)
-	_ = s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM activity WHERE kind='solved' AND datetime(created_at) < datetime('now','-7 day') AND datetime(created_at) >= datetime('now','-14 day')`).Scan(&st.SolvedPrev7Days)
+	_ = s.db.QueryRowContext(ctx, `SELECT COUNT(DISTINCT slug) FROM activity WHERE kind='solved' AND datetime(created_at) >= datetime('now','-7 day')`).Scan(&st.SolvedLast7Days)
+	_ = s.db.QueryRowContext(ctx, `SELECT COUNT(DISTINCT slug) FROM activity WHERE kind='solved' AND datetime(created_at) < datetime('now','-7 day') AND datetime(created_at) >= datetime('now','-14 day')`).Scan(&st.SolvedPrev7Days)
+
+	if d, err := s.TodayDaily(ctx); err == nil {
+		st.DailySlug = d.Slug
+		st.DailyDone = d.CompletedAt != ""
+	}
+	st.DailyStreak, _ = s.DailyStreak(ctx)
 
-	rows, err := s.db.QueryContext(ctx, `SELECT slug, kind, payload, created_at FROM activity ORDER BY id DESC LIMIT 10`)
+	rows, err := s.db.QueryContext(ctx, `SELECT slug, kind, payload, created_at FROM activity ORDER BY created_at DESC, id DESC LIMIT 10`)
 	if err == nil {
 		defer rows.Close()
 		for rows.Next() {
@@ -351,3 +1094,10 @@ func boolToInt(v bool) int {
 	}
 	return 0
 }
+
+func unixOrZero(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
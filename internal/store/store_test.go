@@ -0,0 +1,86 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func openTest(t *testing.T) *Store {
+	t.Helper()
+	s, err := Open(filepath.Join(t.TempDir(), "leet.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = s.Close() })
+	return s
+}
+
+func TestDailyFollowsSiteDate(t *testing.T) {
+	ctx := context.Background()
+	s := openTest(t)
+
+	// A site ahead of UTC is already on tomorrow's daily.
+	now := time.Now().UTC()
+	yesterday := now.Format("2006-01-02")
+	today := now.AddDate(0, 0, 1).Format("2006-01-02")
+	if err := s.RecordDaily(ctx, yesterday, "two-sum", now.Add(-time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.db.ExecContext(ctx, `UPDATE dailies SET completed_at=CURRENT_TIMESTAMP`); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.RecordDaily(ctx, today, "add-two-numbers", now.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := s.TodayDaily(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Date != today || d.Slug != "add-two-numbers" || d.CompletedAt != "" {
+		t.Fatalf("today's daily = %+v, want the open %s daily", d, today)
+	}
+	if n, _ := s.DailyStreak(ctx); n != 1 {
+		t.Errorf("streak with today open = %d, want 1", n)
+	}
+
+	if err := s.SaveSubmissionResult(ctx, "add-two-numbers", "Accepted", "", ""); err != nil {
+		t.Fatal(err)
+	}
+	if d, _ := s.TodayDaily(ctx); d.CompletedAt == "" {
+		t.Error("accepted daily was not marked completed")
+	}
+	if n, _ := s.DailyStreak(ctx); n != 2 {
+		t.Errorf("streak after solving today = %d, want 2", n)
+	}
+}
+
+func TestDailyRollsOverWhenItEnds(t *testing.T) {
+	ctx := context.Background()
+	s := openTest(t)
+
+	// The recorded daily ended over a day ago, so the site is two days on.
+	if err := s.RecordDaily(ctx, "2026-03-01", "two-sum", time.Now().Add(-25*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := s.dailyToday(ctx); got != "2026-03-03" {
+		t.Errorf("site day = %q, want 2026-03-03", got)
+	}
+	if _, err := s.TodayDaily(ctx); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("TodayDaily error = %v, want sql.ErrNoRows", err)
+	}
+	if err := s.SaveSubmissionResult(ctx, "two-sum", "Accepted", "", ""); err != nil {
+		t.Fatal(err)
+	}
+	var done string
+	if err := s.db.QueryRowContext(ctx, `SELECT completed_at FROM dailies`).Scan(&done); err != nil {
+		t.Fatal(err)
+	}
+	if done != "" {
+		t.Error("a daily that already ended was marked completed")
+	}
+}
@@ -0,0 +1,774 @@
+package tester
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+type goSignature struct {
+	Name   string
+	Params int
+	// Design is set for design problems, where Name is the Constructor and
+	// each case replays an operation list against the returned value.
+	Design bool
+}
+
+// goAutoImports mirrors the packages LeetCode makes available to Go
+// solutions without an explicit import.
+var goAutoImports = map[string]string{
+	"bits":    "math/bits",
+	"bytes":   "bytes",
+	"cmp":     "cmp",
+	"fmt":     "fmt",
+	"heap":    "container/heap",
+	"list":    "container/list",
+	"maps":    "maps",
+	"math":    "math",
+	"rand":    "math/rand",
+	"ring":    "container/ring",
+	"slices":  "slices",
+	"sort":    "sort",
+	"strconv": "strconv",
+	"strings": "strings",
+	"unicode": "unicode",
+}
+
+func RunGo(solutionPath string, suite Suite) (Result, error) {
+	src, err := os.ReadFile(solutionPath)
+	if err != nil {
+		return Result{}, err
+	}
+	prepared, sig, err := prepareGoSource(solutionPath, string(src))
+	if err != nil {
+		return Result{Passed: false, FailedCount: 1, Status: StatusCompileError, Output: err.Error()}, nil
+	}
+
+	dir, err := os.MkdirTemp("", "leetcli-go-*")
+	if err != nil {
+		return Result{}, err
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"go.mod":                  "module leetcli_harness\n\ngo 1.21\n",
+		"solution.go":             prepared.source,
+		"leetcli_main.go":         strings.NewReplacer("__LEETCLI_FUNC__", sig.Name, "__LEETCLI_DESIGN__", fmt.Sprint(sig.Design)).Replace(goHarness),
+		"leetcli_limits_unix.go":  goLimitsUnix,
+		"leetcli_limits_other.go": goLimitsOther,
+	}
+	if prepared.types != "" {
+		files["leetcli_types.go"] = prepared.types
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			return Result{}, err
+		}
+	}
+
+	bin := filepath.Join(dir, "runner")
+	build := exec.Command("go", "build", "-o", bin, ".")
+	build.Dir = dir
+	build.Env = append(os.Environ(), "GO111MODULE=on", "GOWORK=off", "GOFLAGS=-mod=mod")
+	var buildOut bytes.Buffer
+	build.Stdout = &buildOut
+	build.Stderr = &buildOut
+	if err := build.Run(); err != nil {
+		out := strings.TrimPrefix(buildOut.String(), "# leetcli_harness\n")
+		out = strings.ReplaceAll(out, "./solution.go", solutionPath)
+		return Result{Passed: false, FailedCount: 1, Status: StatusCompileError, Output: out}, nil
+	}
+
+	payload := map[string]any{
+		"cases":           goCases(sig.Params, suite),
+		"case_timeout_ms": suite.Limits.CaseTimeout.Milliseconds(),
+		"memory_mb":       suite.Limits.MemoryMB,
+	}
+	pb, _ := json.Marshal(payload)
+	return runHarness(suite.Limits, bin, string(pb)), nil
+}
+
+type goPrepared struct {
+	source string
+	types  string
+}
+
+// prepareGoSource turns a LeetCode-style Go snippet into a compilable file in
+// package main, adding the imports and helper types LeetCode provides
+// implicitly. Extra declarations are kept on the first line so compiler
+// positions still match the user's file.
+func prepareGoSource(filename, src string) (goPrepared, goSignature, error) {
+	hasPackage := goPackageLine(src)
+	parseSrc := src
+	if !hasPackage {
+		parseSrc = "package main;" + src
+	}
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filename, parseSrc, 0)
+	if err != nil {
+		return goPrepared{}, goSignature{}, err
+	}
+	if f.Name.Name != "main" {
+		return goPrepared{}, goSignature{}, fmt.Errorf("solution.go must be in package main (found package %s)", f.Name.Name)
+	}
+
+	var sig goSignature
+	for _, decl := range f.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil && fn.Name.Name == "Constructor" {
+			sig = goSignature{Name: "Constructor", Params: 2, Design: true}
+			break
+		}
+	}
+	for _, decl := range f.Decls {
+		if sig.Design {
+			break
+		}
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil || fn.Name.Name == "main" || fn.Name.Name == "init" {
+			continue
+		}
+		sig.Name = fn.Name.Name
+		for _, field := range fn.Type.Params.List {
+			n := len(field.Names)
+			if n == 0 {
+				n = 1
+			}
+			sig.Params += n
+		}
+		break
+	}
+	if sig.Name == "" {
+		return goPrepared{}, goSignature{}, fmt.Errorf("no top-level function found in solution.go")
+	}
+
+	imported := map[string]bool{}
+	for _, imp := range f.Imports {
+		imported[strings.Trim(imp.Path.Value, `"`)] = true
+	}
+	var missing []string
+	needTypes := map[string]bool{}
+	for _, id := range f.Unresolved {
+		if path, ok := goAutoImports[id.Name]; ok && !imported[path] {
+			imported[path] = true
+			missing = append(missing, path)
+		}
+		if _, ok := goNodeTypes[id.Name]; ok {
+			needTypes[id.Name] = true
+		}
+	}
+	sort.Strings(missing)
+
+	prefix := ""
+	if !hasPackage {
+		prefix = "package main; "
+	}
+	if len(missing) > 0 {
+		quoted := make([]string, 0, len(missing))
+		for _, m := range missing {
+			quoted = append(quoted, fmt.Sprintf("%q", m))
+		}
+		importDecl := "import (" + strings.Join(quoted, "; ") + ")"
+		if hasPackage {
+			src = insertAfterPackageClause(src, "; "+importDecl)
+		} else {
+			prefix += importDecl + "; "
+		}
+	}
+
+	var types strings.Builder
+	names := make([]string, 0, len(needTypes))
+	for name := range needTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if len(names) > 0 {
+		types.WriteString("package main\n")
+		for _, name := range names {
+			types.WriteString("\n" + goNodeTypes[name])
+		}
+	}
+	return goPrepared{source: prefix + src, types: types.String()}, sig, nil
+}
+
+var goNodeTypes = map[string]string{
+	"ListNode": "type ListNode struct {\n\tVal  int\n\tNext *ListNode\n}\n",
+	"TreeNode": "type TreeNode struct {\n\tVal   int\n\tLeft  *TreeNode\n\tRight *TreeNode\n}\n",
+}
+
+func goPackageLine(src string) bool {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "solution.go", src, parser.PackageClauseOnly)
+	return err == nil && f.Name != nil
+}
+
+func insertAfterPackageClause(src, text string) string {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "solution.go", src, parser.PackageClauseOnly)
+	if err != nil {
+		return src
+	}
+	off := fset.Position(f.Name.End()).Offset
+	return src[:off] + text + src[off:]
+}
+
+func goCases(arity int, suite Suite) []map[string]any {
+	cases := make([]map[string]any, 0)
+	lines := exampleLines(suite.ExampleTests)
+	if arity > 0 {
+		for i := 0; i+arity <= len(lines); i += arity {
+			args := make([]json.RawMessage, 0, arity)
+			for _, l := range lines[i : i+arity] {
+				args = append(args, json.RawMessage(l))
+			}
+			idx := i / arity
+			c := map[string]any{"name": fmt.Sprintf("example %d", idx+1), "args": args}
+			if idx < len(suite.ExampleOutputs) && json.Valid([]byte(suite.ExampleOutputs[idx])) {
+				c["expected"] = json.RawMessage(suite.ExampleOutputs[idx])
+			}
+			cases = append(cases, c)
+		}
+	}
+	for i, uc := range suite.UserCases {
+		in, ok := uc.Input.([]any)
+		if !ok {
+			in = []any{uc.Input}
+		}
+		args := make([]json.RawMessage, 0, len(in))
+		for _, v := range in {
+			b, _ := json.Marshal(v)
+			args = append(args, b)
+		}
+		c := map[string]any{"name": fmt.Sprintf("tests.json #%d", i+1), "args": args}
+		if uc.Expected != nil {
+			c["expected"] = uc.Expected
+		}
+		cases = append(cases, c)
+	}
+	return cases
+}
+
+func exampleLines(s string) []string {
+	out := make([]string, 0)
+	for _, l := range strings.Split(s, "\n") {
+		l = strings.TrimSpace(l)
+		if l != "" {
+			out = append(out, l)
+		}
+	}
+	return out
+}
+
+const goHarness = `package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"reflect"
+	"runtime/debug"
+	"strings"
+	"time"
+)
+
+type leetcliCase struct {
+	Name     string            ` + "`json:\"name\"`" + `
+	Args     []json.RawMessage ` + "`json:\"args\"`" + `
+	Expected json.RawMessage   ` + "`json:\"expected\"`" + `
+}
+
+func main() {
+	var payload struct {
+		Cases         []leetcliCase ` + "`json:\"cases\"`" + `
+		CaseTimeoutMS int64         ` + "`json:\"case_timeout_ms\"`" + `
+		MemoryMB      int           ` + "`json:\"memory_mb\"`" + `
+	}
+	if err := json.Unmarshal([]byte(os.Args[1]), &payload); err != nil {
+		fmt.Fprintln(os.Stderr, "bad payload:", err)
+		os.Exit(2)
+	}
+	leetcliLimitMemory(payload.MemoryMB)
+	fn := reflect.ValueOf(__LEETCLI_FUNC__)
+	call := leetcliCall
+	if __LEETCLI_DESIGN__ {
+		call = leetcliReplay
+	}
+	realStdout := os.Stdout
+	failed := 0
+	results := make([]map[string]any, 0, len(payload.Cases))
+	for i, c := range payload.Cases {
+		res := map[string]any{
+			"name":     c.Name,
+			"input":    leetcliInput(c.Args),
+			"expected": string(c.Expected),
+			"passed":   true,
+		}
+		capture, _ := os.CreateTemp("", "leetcli-stdout-*")
+		if capture != nil {
+			os.Stdout = capture
+		}
+		start := time.Now()
+		done := make(chan leetcliOutcome, 1)
+		go func(args []json.RawMessage) {
+			got, err := call(fn, args)
+			done <- leetcliOutcome{got: got, err: err}
+		}(c.Args)
+		var deadline <-chan time.Time
+		if payload.CaseTimeoutMS > 0 {
+			deadline = time.After(time.Duration(payload.CaseTimeoutMS) * time.Millisecond)
+		}
+		var got any
+		var err error
+		timedOut := false
+		select {
+		case o := <-done:
+			got, err = o.got, o.err
+		case <-deadline:
+			timedOut = true
+		}
+		res["elapsed_ms"] = float64(time.Since(start).Microseconds()) / 1000
+		os.Stdout = realStdout
+		if capture != nil {
+			b, _ := os.ReadFile(capture.Name())
+			capture.Close()
+			os.Remove(capture.Name())
+			res["stdout"] = string(b)
+		}
+		switch {
+		case timedOut:
+			res["status"] = "Time Limit Exceeded"
+			res["error"] = fmt.Sprintf("Time Limit Exceeded: case ran longer than %dms", payload.CaseTimeoutMS)
+			res["passed"] = false
+		case err != nil:
+			res["status"] = "Runtime Error"
+			var he *leetcliHarnessError
+			if errors.As(err, &he) {
+				res["status"] = "Harness Error"
+			}
+			res["error"] = err.Error()
+			res["passed"] = false
+		default:
+			gb, _ := json.Marshal(got)
+			res["actual"] = string(gb)
+			if len(c.Expected) > 0 {
+				var want any
+				if err := json.Unmarshal(c.Expected, &want); err != nil {
+					res["status"] = "Runtime Error"
+					res["error"] = "bad expected value: " + err.Error()
+					res["passed"] = false
+				} else if !leetcliEqual(got, want) {
+					res["status"] = "Wrong Answer"
+					res["passed"] = false
+				} else {
+					res["status"] = "Accepted"
+				}
+			}
+		}
+		if res["passed"] == false {
+			failed++
+		}
+		results = append(results, res)
+		if timedOut {
+			// The solution goroutine cannot be stopped, so the remaining
+			// cases are reported as not run.
+			for _, rest := range payload.Cases[i+1:] {
+				results = append(results, map[string]any{
+					"name":     rest.Name,
+					"input":    leetcliInput(rest.Args),
+					"expected": string(rest.Expected),
+					"status":   "Not Run",
+					"error":    "not run: an earlier case exceeded the time limit",
+					"passed":   false,
+				})
+			}
+			break
+		}
+	}
+	summary, _ := json.Marshal(map[string]any{"passed": failed == 0, "failed": failed, "cases": results})
+	fmt.Fprintln(realStdout, string(summary))
+}
+
+type leetcliOutcome struct {
+	got any
+	err error
+}
+
+// leetcliHarnessError marks a case the harness could not run, as opposed to
+// a failure of the solution.
+type leetcliHarnessError struct{ msg string }
+
+func (e *leetcliHarnessError) Error() string { return e.msg }
+
+func leetcliInput(args []json.RawMessage) string {
+	inputs := make([]string, 0, len(args))
+	for _, a := range args {
+		inputs = append(inputs, string(a))
+	}
+	return strings.Join(inputs, "\n")
+}
+
+func leetcliArgs(t reflect.Type, raw []json.RawMessage) ([]reflect.Value, error) {
+	if len(raw) != t.NumIn() {
+		return nil, fmt.Errorf("expected %d arguments, got %d", t.NumIn(), len(raw))
+	}
+	args := make([]reflect.Value, t.NumIn())
+	for i := range raw {
+		var v any
+		if err := json.Unmarshal(raw[i], &v); err != nil {
+			return nil, fmt.Errorf("argument %d: %v", i+1, err)
+		}
+		arg := reflect.New(t.In(i)).Elem()
+		if err := leetcliAssign(arg, v); err != nil {
+			return nil, fmt.Errorf("argument %d: %w", i+1, err)
+		}
+		args[i] = arg
+	}
+	return args, nil
+}
+
+// leetcliReplay runs a design-problem case: raw[0] lists the operations
+// (constructor first) and raw[1] their argument lists.
+func leetcliReplay(ctor reflect.Value, raw []json.RawMessage) (out any, err error) {
+	if len(raw) != 2 {
+		return nil, fmt.Errorf("design cases need an operation list and an argument list, got %d values", len(raw))
+	}
+	var ops []string
+	var argLists [][]json.RawMessage
+	if err := json.Unmarshal(raw[0], &ops); err != nil {
+		return nil, fmt.Errorf("operations: %v", err)
+	}
+	if err := json.Unmarshal(raw[1], &argLists); err != nil {
+		return nil, fmt.Errorf("arguments: %v", err)
+	}
+	if len(ops) == 0 || len(ops) != len(argLists) {
+		return nil, fmt.Errorf("got %d operations and %d argument lists", len(ops), len(argLists))
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v\n%s", r, debug.Stack())
+		}
+	}()
+	args, err := leetcliArgs(ctor.Type(), argLists[0])
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ops[0], err)
+	}
+	created := ctor.Call(args)[0]
+	obj := reflect.New(created.Type())
+	obj.Elem().Set(created)
+	results := []any{nil}
+	for i := 1; i < len(ops); i++ {
+		name := strings.ToUpper(ops[i][:1]) + ops[i][1:]
+		m := obj.MethodByName(name)
+		if !m.IsValid() {
+			return nil, fmt.Errorf("%s has no method %s", created.Type(), name)
+		}
+		args, err := leetcliArgs(m.Type(), argLists[i])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", ops[i], err)
+		}
+		res := m.Call(args)
+		if len(res) == 0 {
+			results = append(results, nil)
+			continue
+		}
+		results = append(results, leetcliExport(res[0]))
+	}
+	return results, nil
+}
+
+func leetcliCall(fn reflect.Value, raw []json.RawMessage) (out any, err error) {
+	args, err := leetcliArgs(fn.Type(), raw)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v\n%s", r, debug.Stack())
+		}
+	}()
+	res := fn.Call(args)
+	if len(res) == 0 {
+		if len(args) == 0 {
+			return nil, nil
+		}
+		return leetcliExport(args[0]), nil
+	}
+	return leetcliExport(res[0]), nil
+}
+
+func leetcliAssign(dst reflect.Value, v any) error {
+	if v == nil {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+	switch dst.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int64:
+		f, ok := v.(float64)
+		if !ok {
+			return fmt.Errorf("cannot use %v as %s", v, dst.Type())
+		}
+		dst.SetInt(int64(f))
+	case reflect.Int32:
+		switch x := v.(type) {
+		case float64:
+			dst.SetInt(int64(x))
+		case string:
+			r := []rune(x)
+			if len(r) != 1 {
+				return fmt.Errorf("cannot use %q as rune", x)
+			}
+			dst.SetInt(int64(r[0]))
+		default:
+			return fmt.Errorf("cannot use %v as %s", v, dst.Type())
+		}
+	case reflect.Uint8:
+		switch x := v.(type) {
+		case float64:
+			dst.SetUint(uint64(x))
+		case string:
+			if len(x) != 1 {
+				return fmt.Errorf("cannot use %q as byte", x)
+			}
+			dst.SetUint(uint64(x[0]))
+		default:
+			return fmt.Errorf("cannot use %v as %s", v, dst.Type())
+		}
+	case reflect.Uint, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		f, ok := v.(float64)
+		if !ok {
+			return fmt.Errorf("cannot use %v as %s", v, dst.Type())
+		}
+		dst.SetUint(uint64(f))
+	case reflect.Float32, reflect.Float64:
+		f, ok := v.(float64)
+		if !ok {
+			return fmt.Errorf("cannot use %v as %s", v, dst.Type())
+		}
+		dst.SetFloat(f)
+	case reflect.Bool:
+		b, ok := v.(bool)
+		if !ok {
+			return fmt.Errorf("cannot use %v as bool", v)
+		}
+		dst.SetBool(b)
+	case reflect.String:
+		s, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("cannot use %v as string", v)
+		}
+		dst.SetString(s)
+	case reflect.Slice:
+		items, ok := v.([]any)
+		if !ok {
+			return fmt.Errorf("cannot use %v as %s", v, dst.Type())
+		}
+		s := reflect.MakeSlice(dst.Type(), len(items), len(items))
+		for i, it := range items {
+			if err := leetcliAssign(s.Index(i), it); err != nil {
+				return err
+			}
+		}
+		dst.Set(s)
+	case reflect.Interface:
+		dst.Set(reflect.ValueOf(v))
+	case reflect.Ptr:
+		items, ok := v.([]any)
+		if !ok {
+			return fmt.Errorf("cannot use %v as %s", v, dst.Type())
+		}
+		switch dst.Type().Elem().Name() {
+		case "ListNode":
+			return leetcliBuildList(dst, items)
+		case "TreeNode":
+			return leetcliBuildTree(dst, items)
+		}
+		return &leetcliHarnessError{fmt.Sprintf("the local Go harness cannot build %s arguments; try leet test --remote", dst.Type())}
+	default:
+		return &leetcliHarnessError{fmt.Sprintf("the local Go harness cannot build %s arguments; try leet test --remote", dst.Type())}
+	}
+	return nil
+}
+
+// leetcliBuildList builds a linked list from LeetCode's array form.
+func leetcliBuildList(dst reflect.Value, items []any) error {
+	head := reflect.Zero(dst.Type())
+	for i := len(items) - 1; i >= 0; i-- {
+		node := reflect.New(dst.Type().Elem())
+		if err := leetcliAssign(node.Elem().FieldByName("Val"), items[i]); err != nil {
+			return err
+		}
+		node.Elem().FieldByName("Next").Set(head)
+		head = node
+	}
+	dst.Set(head)
+	return nil
+}
+
+// leetcliBuildTree builds a binary tree from LeetCode's level-order form,
+// where null marks a missing child.
+func leetcliBuildTree(dst reflect.Value, items []any) error {
+	if len(items) == 0 || items[0] == nil {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+	newNode := func(v any) (reflect.Value, error) {
+		node := reflect.New(dst.Type().Elem())
+		return node, leetcliAssign(node.Elem().FieldByName("Val"), v)
+	}
+	root, err := newNode(items[0])
+	if err != nil {
+		return err
+	}
+	queue := []reflect.Value{root}
+	for i := 1; len(queue) > 0 && i < len(items); {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, side := range []string{"Left", "Right"} {
+			if i >= len(items) {
+				break
+			}
+			if items[i] != nil {
+				child, err := newNode(items[i])
+				if err != nil {
+					return err
+				}
+				cur.Elem().FieldByName(side).Set(child)
+				queue = append(queue, child)
+			}
+			i++
+		}
+	}
+	dst.Set(root)
+	return nil
+}
+
+func leetcliExport(v reflect.Value) any {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int())
+	case reflect.Uint8:
+		return string([]byte{byte(v.Uint())})
+	case reflect.Uint, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.String:
+		return v.String()
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return []any{}
+		}
+		out := make([]any, v.Len())
+		for i := range out {
+			out[i] = leetcliExport(v.Index(i))
+		}
+		return out
+	case reflect.Ptr, reflect.Interface:
+		if v.Kind() == reflect.Ptr {
+			// Lists and trees use LeetCode's array form; empty ones are [].
+			switch v.Type().Elem().Name() {
+			case "ListNode":
+				return leetcliExportList(v)
+			case "TreeNode":
+				return leetcliExportTree(v)
+			}
+		}
+		if v.IsNil() {
+			return nil
+		}
+		return leetcliExport(v.Elem())
+	default:
+		return v.Interface()
+	}
+}
+
+// leetcliExportList serializes a linked list as an array, stopping at a
+// cycle.
+func leetcliExportList(head reflect.Value) any {
+	out := []any{}
+	seen := map[uintptr]bool{}
+	for n := head; !n.IsNil() && !seen[n.Pointer()]; n = n.Elem().FieldByName("Next") {
+		seen[n.Pointer()] = true
+		out = append(out, leetcliExport(n.Elem().FieldByName("Val")))
+	}
+	return out
+}
+
+// leetcliExportTree serializes a binary tree in level order with trailing
+// nulls trimmed.
+func leetcliExportTree(root reflect.Value) any {
+	out := []any{}
+	if root.IsNil() {
+		return out
+	}
+	queue := []reflect.Value{root}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		if n.IsNil() {
+			out = append(out, nil)
+			continue
+		}
+		out = append(out, leetcliExport(n.Elem().FieldByName("Val")))
+		queue = append(queue, n.Elem().FieldByName("Left"), n.Elem().FieldByName("Right"))
+	}
+	for len(out) > 0 && out[len(out)-1] == nil {
+		out = out[:len(out)-1]
+	}
+	return out
+}
+
+func leetcliEqual(a, b any) bool {
+	switch x := a.(type) {
+	case float64:
+		y, ok := b.(float64)
+		return ok && math.Abs(x-y) <= 1e-5
+	case []any:
+		y, ok := b.([]any)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !leetcliEqual(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(a, b)
+	}
+}
+`
+
+const goLimitsUnix = `//go:build unix
+
+package main
+
+import "syscall"
+
+func leetcliLimitMemory(mb int) {
+	if mb <= 0 {
+		return
+	}
+	limit := uint64(mb) << 20
+	_ = syscall.Setrlimit(syscall.RLIMIT_AS, &syscall.Rlimit{Cur: limit, Max: limit})
+}
+`
+
+const goLimitsOther = `//go:build !unix
+
+package main
+
+func leetcliLimitMemory(mb int) {}
+`
@@ -0,0 +1,14 @@
+//go:build !unix
+
+package tester
+
+import "os/exec"
+
+func isolate(cmd *exec.Cmd) {
+	cmd.Cancel = func() error {
+		if cmd.Process == nil {
+			return nil
+		}
+		return cmd.Process.Kill()
+	}
+}
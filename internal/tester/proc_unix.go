@@ -0,0 +1,20 @@
+//go:build unix
+
+package tester
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// isolate runs the harness in its own process group so a timeout kills
+// everything the solution spawned, not just the direct child.
+func isolate(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		if cmd.Process == nil {
+			return nil
+		}
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+}
@@ -2,13 +2,18 @@ package tester
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"regexp"
 	"strings"
+	"time"
+
+	"leetcli/internal/lang"
 )
 
 type UserTestCase struct {
@@ -16,18 +21,85 @@ type UserTestCase struct {
 	Expected any `json:"expected"`
 }
 
+// Suite is everything a runner executes against a solution: the example
+// inputs from the problem (one argument per line), their expected outputs
+// and the user's cases from tests.json.
+type Suite struct {
+	ExampleTests   string
+	ExampleOutputs []string
+	UserCases      []UserTestCase
+	Limits         Limits
+}
+
+// Limits bound a test run. Zero values disable the corresponding limit.
+type Limits struct {
+	CaseTimeout time.Duration
+	RunTimeout  time.Duration
+	MemoryMB    int
+}
+
+// Result is the outcome of a run. FailedCount counts the cases that ran and
+// failed; NotRun counts those skipped after a time limit stopped the run.
 type Result struct {
 	Passed      bool
 	FailedCount int
+	NotRun      int
+	Status      string
 	Output      string
+	Cases       []CaseResult
+}
+
+// CaseResult is the outcome of a single example or tests.json case. Input,
+// Expected and Actual use LeetCode's serialization (one argument per line for
+// Input); Expected is empty when the case has no expected answer.
+type CaseResult struct {
+	Name     string
+	Status   string
+	Input    string
+	Expected string
+	Actual   string
+	Stdout   string
+	Elapsed  time.Duration
+	Error    string
+	Passed   bool
 }
 
-func RunPython(solutionPath, exampleTests string, userCases []UserTestCase) (Result, error) {
+// Status values follow LeetCode's verdict names.
+const (
+	StatusAccepted     = "Accepted"
+	StatusWrongAnswer  = "Wrong Answer"
+	StatusRuntimeError = "Runtime Error"
+	StatusCompileError = "Compile Error"
+	StatusTimeLimit    = "Time Limit Exceeded"
+	StatusMemoryLimit  = "Memory Limit Exceeded"
+	// StatusNotRun marks cases skipped after a time limit stopped the run,
+	// and StatusHarnessError cases the local harness cannot execute. Neither
+	// is a LeetCode verdict.
+	StatusNotRun       = "Not Run"
+	StatusHarnessError = "Harness Error"
+)
+
+func Run(l lang.Language, solutionPath string, suite Suite) (Result, error) {
+	switch l.Runner {
+	case "python":
+		return RunPython(solutionPath, suite)
+	case "go":
+		return RunGo(solutionPath, suite)
+	default:
+		return Result{}, fmt.Errorf("no local runner for %s; use leet submit to check it remotely", l.Name)
+	}
+}
+
+func RunPython(solutionPath string, suite Suite) (Result, error) {
 	method := detectMethod(solutionPath)
 	payload := map[string]any{
-		"method":  method,
-		"example": splitExampleCases(exampleTests),
-		"user":    userCases,
+		"method":          method,
+		"design_class":    detectDesignClass(solutionPath),
+		"example_lines":   exampleLines(suite.ExampleTests),
+		"example_outputs": suite.ExampleOutputs,
+		"user":            suite.UserCases,
+		"case_timeout_ms": suite.Limits.CaseTimeout.Milliseconds(),
+		"memory_mb":       suite.Limits.MemoryMB,
 	}
 	pb, _ := json.Marshal(payload)
 
@@ -37,24 +109,99 @@ func RunPython(solutionPath, exampleTests string, userCases []UserTestCase) (Res
 	}
 	defer os.Remove(tmpRunner)
 
-	cmd := exec.Command("python3", tmpRunner, solutionPath, string(pb))
+	return runHarness(suite.Limits, "python3", tmpRunner, solutionPath, string(pb)), nil
+}
+
+// runHarness executes a runner process in its own process group, killing the
+// whole group once the run-level deadline passes.
+func runHarness(limits Limits, name string, args ...string) Result {
+	ctx := context.Background()
+	if limits.RunTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, limits.RunTimeout)
+		defer cancel()
+	}
+	cmd := exec.CommandContext(ctx, name, args...)
+	isolate(cmd)
+	cmd.WaitDelay = time.Second
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 	err := cmd.Run()
 	out := stdout.String() + stderr.String()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		out = fmt.Sprintf("run exceeded the %s time limit and was killed\n", limits.RunTimeout) + out
+		return Result{Passed: false, FailedCount: 1, Status: StatusTimeLimit, Output: out}
+	}
 	if err != nil {
-		return Result{Passed: false, FailedCount: 1, Output: out}, nil
+		status := StatusRuntimeError
+		if isOutOfMemory(stderr.String()) {
+			status = StatusMemoryLimit
+		}
+		return Result{Passed: false, FailedCount: 1, Status: status, Output: out}
 	}
+	return parseSummary(stdout.String(), stderr.String())
+}
 
+func isOutOfMemory(stderr string) bool {
+	return strings.Contains(stderr, "MemoryError") || strings.Contains(stderr, "out of memory") || strings.Contains(stderr, "cannot allocate memory")
+}
+
+// parseSummary reads the JSON summary the harnesses print as their last
+// stdout line; anything printed before it is diagnostic output.
+func parseSummary(stdout, stderr string) Result {
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
 	var parsed struct {
 		Passed bool `json:"passed"`
 		Failed int  `json:"failed"`
+		Cases  []struct {
+			Name      string  `json:"name"`
+			Status    string  `json:"status"`
+			Input     string  `json:"input"`
+			Expected  string  `json:"expected"`
+			Actual    string  `json:"actual"`
+			Stdout    string  `json:"stdout"`
+			ElapsedMS float64 `json:"elapsed_ms"`
+			Error     string  `json:"error"`
+			Passed    bool    `json:"passed"`
+		} `json:"cases"`
+	}
+	if jErr := json.Unmarshal([]byte(lines[len(lines)-1]), &parsed); jErr != nil {
+		return Result{Passed: false, FailedCount: 1, Status: StatusRuntimeError, Output: stdout + stderr}
 	}
-	if jErr := json.Unmarshal(stdout.Bytes(), &parsed); jErr != nil {
-		return Result{Passed: false, FailedCount: 1, Output: out}, nil
+	res := Result{
+		Passed:      parsed.Passed,
+		FailedCount: parsed.Failed,
+		Status:      StatusAccepted,
+		Output:      strings.Join(lines[:len(lines)-1], "\n") + stderr,
 	}
-	return Result{Passed: parsed.Passed, FailedCount: parsed.Failed, Output: out}, nil
+	for _, c := range parsed.Cases {
+		res.Cases = append(res.Cases, CaseResult{
+			Name:     c.Name,
+			Status:   c.Status,
+			Input:    c.Input,
+			Expected: c.Expected,
+			Actual:   c.Actual,
+			Stdout:   c.Stdout,
+			Elapsed:  time.Duration(c.ElapsedMS * float64(time.Millisecond)),
+			Error:    c.Error,
+			Passed:   c.Passed,
+		})
+		if c.Status == StatusNotRun {
+			res.NotRun++
+			continue
+		}
+		if !c.Passed && res.Status == StatusAccepted {
+			res.Status = c.Status
+			if res.Status == "" {
+				res.Status = StatusWrongAnswer
+			}
+		}
+	}
+	if !res.Passed && res.Status == StatusAccepted {
+		res.Status = StatusWrongAnswer
+	}
+	return res
 }
 
 func LoadUserCases(problemDir string) ([]UserTestCase, error) {
@@ -73,6 +220,72 @@ func LoadUserCases(problemDir string) ([]UserTestCase, error) {
 	return tc, nil
 }
 
+// AddUserCase appends c to the problem's tests.json unless an identical case
+// is already there. It reports whether the file changed.
+func AddUserCase(problemDir string, c UserTestCase) (bool, error) {
+	cases, err := LoadUserCases(problemDir)
+	if err != nil {
+		return false, err
+	}
+	want, _ := json.Marshal(c)
+	for _, existing := range cases {
+		if got, _ := json.Marshal(existing); bytes.Equal(got, want) {
+			return false, nil
+		}
+	}
+	cases = append(cases, c)
+	b, err := json.MarshalIndent(cases, "", "  ")
+	if err != nil {
+		return false, err
+	}
+	if err := os.WriteFile(filepath.Join(problemDir, "tests.json"), append(b, '\n'), 0o644); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// DataInput renders the suite in LeetCode's data_input format (one JSON
+// argument per line) for running on the judge: the example tests followed by
+// the cases from tests.json.
+func DataInput(suite Suite) string {
+	lines := exampleLines(suite.ExampleTests)
+	for _, uc := range suite.UserCases {
+		in, ok := uc.Input.([]any)
+		if !ok {
+			in = []any{uc.Input}
+		}
+		for _, v := range in {
+			b, _ := json.Marshal(v)
+			lines = append(lines, string(b))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+var pyClassRe = regexp.MustCompile(`(?m)^class\s+([A-Za-z_][A-Za-z0-9_]*)`)
+
+// detectDesignClass returns the class to replay for design problems (e.g.
+// LRUCache), or "" when the file defines the usual Solution class.
+func detectDesignClass(solutionPath string) string {
+	b, err := os.ReadFile(solutionPath)
+	if err != nil {
+		return ""
+	}
+	design := ""
+	for _, m := range pyClassRe.FindAllSubmatch(b, -1) {
+		switch name := string(m[1]); name {
+		case "Solution":
+			return ""
+		case "ListNode", "TreeNode", "Node":
+		default:
+			if design == "" {
+				design = name
+			}
+		}
+	}
+	return design
+}
+
 func detectMethod(solutionPath string) string {
 	b, err := os.ReadFile(solutionPath)
 	if err != nil {
@@ -95,98 +308,425 @@ func detectMethod(solutionPath string) string {
 	return string(m[1])
 }
 
-func splitExampleCases(s string) []string {
-	s = strings.TrimSpace(s)
-	if s == "" {
-		return nil
-	}
-	parts := strings.Split(s, "\n\n")
-	out := make([]string, 0, len(parts))
-	for _, p := range parts {
-		p = strings.TrimSpace(p)
-		if p != "" {
-			out = append(out, p)
-		}
-	}
-	if len(out) == 0 {
-		return []string{s}
-	}
-	return out
-}
-
 const runnerScript = `
+import collections
+import contextlib
 import importlib.util
+import inspect
+import io
 import json
+import math
+import signal
 import sys
+import time
 import traceback
+import typing
+from typing import List
+
+
+PRELUDE = """
+from typing import *
+from collections import *
+import bisect
+import collections
+import functools
+import heapq
+import itertools
+import math
+import re
+import string
+
+
+class ListNode:
+    def __init__(self, val=0, next=None):
+        self.val = val
+        self.next = next
+
+
+class TreeNode:
+    def __init__(self, val=0, left=None, right=None):
+        self.val = val
+        self.left = left
+        self.right = right
+
+
+class Node:
+    def __init__(self, val=0, neighbors=None):
+        self.val = val
+        self.neighbors = neighbors if neighbors is not None else []
+"""
 
 
 def load_module(path):
     spec = importlib.util.spec_from_file_location("solution_module", path)
     mod = importlib.util.module_from_spec(spec)
+    exec(PRELUDE, mod.__dict__)
     spec.loader.exec_module(mod)
     return mod
 
 
-def parse_args(raw):
-    lines = [l.strip() for l in raw.splitlines() if l.strip()]
-    vals = []
-    for line in lines:
-        if line.startswith("Input:"):
-            line = line[len("Input:"):].strip()
-        if "=" in line and not line.startswith("[") and not line.startswith("{") and not line.startswith("("):
-            chunks = [x.strip() for x in line.split(",") if x.strip()]
-            for c in chunks:
-                if "=" in c:
-                    vals.append(eval(c.split("=", 1)[1].strip()))
-                else:
-                    vals.append(eval(c))
-        else:
-            vals.append(eval(line))
-    return vals
+def parse_value(raw):
+    raw = raw.strip()
+    try:
+        return json.loads(raw)
+    except ValueError:
+        return eval(raw)
+
+
+def normalize(v):
+    if isinstance(v, tuple):
+        v = list(v)
+    if isinstance(v, list):
+        return [normalize(x) for x in v]
+    return v
+
+
+def same(a, b):
+    a, b = normalize(a), normalize(b)
+    if isinstance(a, float) or isinstance(b, float):
+        try:
+            return math.isclose(float(a), float(b), rel_tol=1e-5, abs_tol=1e-5)
+        except (TypeError, ValueError):
+            return False
+    if isinstance(a, list) and isinstance(b, list):
+        return len(a) == len(b) and all(same(x, y) for x, y in zip(a, b))
+    return a == b
+
+
+def show(v):
+    try:
+        return json.dumps(normalize(v), separators=(",", ":"))
+    except (TypeError, ValueError):
+        return repr(v)
+
+
+def unwrap_optional(ann):
+    if typing.get_origin(ann) is typing.Union:
+        args = [a for a in typing.get_args(ann) if a is not type(None)]
+        if len(args) == 1:
+            return args[0]
+    return ann
+
+
+def param_hints(fn):
+    try:
+        hints = typing.get_type_hints(fn)
+    except Exception:
+        hints = {}
+    out = []
+    for name, p in inspect.signature(fn).parameters.items():
+        out.append(hints.get(name, p.annotation))
+    return out
+
+
+def node_kind(ann):
+    if not isinstance(ann, type):
+        return None
+    if ann.__name__ == "ListNode":
+        return "list"
+    if ann.__name__ == "TreeNode":
+        return "tree"
+    try:
+        if "neighbors" in inspect.signature(ann).parameters:
+            return "graph"
+    except (TypeError, ValueError):
+        pass
+    return None
+
+
+def build_list(cls, values):
+    dummy = cur = cls(0)
+    for v in values or []:
+        cur.next = cls(v)
+        cur = cur.next
+    return dummy.next
+
+
+def build_tree(cls, values):
+    if not values or values[0] is None:
+        return None
+    root = cls(values[0])
+    queue = collections.deque([root])
+    i = 1
+    while queue and i < len(values):
+        node = queue.popleft()
+        if i < len(values) and values[i] is not None:
+            node.left = cls(values[i])
+            queue.append(node.left)
+        i += 1
+        if i < len(values) and values[i] is not None:
+            node.right = cls(values[i])
+            queue.append(node.right)
+        i += 1
+    return root
+
+
+def build_graph(cls, adjacency):
+    if not adjacency:
+        return None
+    nodes = [cls(i + 1) for i in range(len(adjacency))]
+    for node, neighbors in zip(nodes, adjacency):
+        node.neighbors = [nodes[n - 1] for n in neighbors]
+    return nodes[0]
+
+
+def find_tree_node(root, val):
+    stack = [root]
+    while stack:
+        node = stack.pop()
+        if node is None:
+            continue
+        if node.val == val:
+            return node
+        stack.extend([node.left, node.right])
+    return None
+
+
+def convert_arg(ann, value, roots):
+    ann = unwrap_optional(ann)
+    if typing.get_origin(ann) in (list, List) and isinstance(value, list):
+        inner = typing.get_args(ann)
+        return [convert_arg(inner[0], v, roots) for v in value] if inner else value
+    kind = node_kind(ann)
+    if kind == "list" and (value is None or isinstance(value, list)):
+        return build_list(ann, value)
+    if kind == "tree":
+        if value is None or isinstance(value, list):
+            root = build_tree(ann, value)
+            roots.append(root)
+            return root
+        # Problems like lowest-common-ancestor pass node values that refer
+        # to nodes of a tree given earlier in the argument list.
+        for root in roots:
+            found = find_tree_node(root, value)
+            if found is not None:
+                return found
+        return ann(value)
+    if kind == "graph" and (value is None or isinstance(value, list)):
+        return build_graph(ann, value)
+    return value
+
+
+def convert_args(hints, args):
+    roots = []
+    return [convert_arg(hints[i] if i < len(hints) else None, a, roots) for i, a in enumerate(args)]
+
+
+def serialize(v):
+    if isinstance(v, (list, tuple)):
+        return [serialize(x) for x in v]
+    if hasattr(v, "neighbors") and hasattr(v, "val"):
+        return serialize_graph(v)
+    if hasattr(v, "left") and hasattr(v, "right") and hasattr(v, "val"):
+        return serialize_tree(v)
+    if hasattr(v, "next") and hasattr(v, "val"):
+        out, seen = [], set()
+        while v is not None and id(v) not in seen:
+            seen.add(id(v))
+            out.append(v.val)
+            v = v.next
+        return out
+    return v
+
+
+def serialize_tree(root):
+    out, queue = [], collections.deque([root])
+    while queue:
+        node = queue.popleft()
+        if node is None:
+            out.append(None)
+            continue
+        out.append(node.val)
+        queue.append(node.left)
+        queue.append(node.right)
+    while out and out[-1] is None:
+        out.pop()
+    return out
+
+
+def serialize_graph(start):
+    seen, queue = {start.val: start}, collections.deque([start])
+    while queue:
+        node = queue.popleft()
+        for n in node.neighbors:
+            if n.val not in seen:
+                seen[n.val] = n
+                queue.append(n)
+    return [[n.val for n in seen[val].neighbors] for val in sorted(seen)]
+
+
+class TimeLimitExceeded(Exception):
+    pass
+
+
+def on_alarm(signum, frame):
+    raise TimeLimitExceeded()
+
+
+def limit_memory(mb):
+    if mb <= 0:
+        return
+    try:
+        import resource
+        limit = mb * 1024 * 1024
+        resource.setrlimit(resource.RLIMIT_AS, (limit, limit))
+    except (ImportError, ValueError, OSError):
+        pass
+
+
+def example_cases(lines, outputs, arity):
+    cases = []
+    if arity <= 0:
+        return cases
+    for i in range(0, len(lines) - arity + 1, arity):
+        idx = i // arity
+        case = {"name": "example %d" % (idx + 1)}
+        try:
+            case["args"] = [parse_value(l) for l in lines[i:i + arity]]
+        except Exception as e:
+            # Keep the raw lines so the case is reported instead of
+            # aborting the whole run.
+            case["args"] = [l.strip() for l in lines[i:i + arity]]
+            case["parse_error"] = "cannot parse example input: %s" % e
+        if idx < len(outputs):
+            try:
+                case["expected"] = parse_value(outputs[idx])
+            except Exception:
+                # An unparsable "Output:" line only loses the comparison.
+                pass
+        cases.append(case)
+    return cases
+
+
+def replay(cls, raw):
+    ops, arg_lists = raw[0], raw[1]
+    if not ops or ops[0] != cls.__name__:
+        raise ValueError("first operation must construct %s, got %r" % (cls.__name__, ops[:1]))
+    obj = cls(*convert_args(param_hints(cls.__init__)[1:], arg_lists[0]))
+    out = [None]
+    for op, args in zip(ops[1:], arg_lists[1:]):
+        method = getattr(obj, op)
+        out.append(serialize(method(*convert_args(param_hints(method), args))))
+    return out
 
 
 def main():
     solution_path = sys.argv[1]
     payload = json.loads(sys.argv[2])
     method_name = payload.get("method")
+    design_class = payload.get("design_class")
+    case_timeout = (payload.get("case_timeout_ms") or 0) / 1000.0
+    if case_timeout > 0 and hasattr(signal, "setitimer"):
+        signal.signal(signal.SIGALRM, on_alarm)
+    else:
+        case_timeout = 0
+    limit_memory(payload.get("memory_mb") or 0)
 
     mod = load_module(solution_path)
-    sol = mod.Solution()
-    if not method_name:
-      methods = [m for m in dir(sol) if not m.startswith("_") and callable(getattr(sol, m))]
-      method_name = methods[0] if methods else None
-    if not method_name:
-      print(json.dumps({"passed": False, "failed": 1}))
-      return
-
-    fn = getattr(sol, method_name)
-    failed = 0
+    if design_class:
+        # Design problems: each case is an operation list plus an argument
+        # list, replayed against a fresh instance of the class.
+        cls = getattr(mod, design_class)
+        arity = 2
 
-    for raw in payload.get("example", []):
-      try:
-        args = parse_args(raw)
-        if len(args) == 1 and isinstance(args[0], tuple):
-          args = list(args[0])
-        fn(*args)
-      except Exception:
-        failed += 1
-        traceback.print_exc()
-
-    for case in payload.get("user", []):
-      try:
+        def call(raw_args):
+            return replay(cls, raw_args)
+    else:
+        sol = mod.Solution()
+        if not method_name:
+            methods = [m for m in dir(sol) if not m.startswith("_") and callable(getattr(sol, m))]
+            method_name = methods[0] if methods else None
+        if not method_name:
+            print(json.dumps({"passed": False, "failed": 1}))
+            return
+
+        fn = getattr(sol, method_name)
+        sig = inspect.signature(fn)
+        arity = len(sig.parameters)
+        in_place = sig.return_annotation is None
+        hints = param_hints(fn)
+        try:
+            returns_node = node_kind(unwrap_optional(typing.get_type_hints(fn).get("return"))) is not None
+        except Exception:
+            returns_node = False
+
+        def call(raw_args):
+            args = convert_args(hints, raw_args)
+            got = fn(*args)
+            if in_place and args:
+                got = args[0]
+            if got is None and returns_node:
+                got = []
+            return got
+
+    cases = example_cases(payload.get("example_lines") or [], payload.get("example_outputs") or [], arity)
+    for i, case in enumerate(payload.get("user") or []):
         args = case.get("input")
         if not isinstance(args, list):
-          args = [args]
-        got = fn(*args)
-        if "expected" in case and case.get("expected") != got:
-          failed += 1
-          print(f"expected={case.get('expected')} got={got}")
-      except Exception:
-        failed += 1
-        traceback.print_exc()
-
-    print(json.dumps({"passed": failed == 0, "failed": failed}))
+            args = [args]
+        c = {"name": "tests.json #%d" % (i + 1), "args": args}
+        if case.get("expected") is not None:
+            c["expected"] = case.get("expected")
+        cases.append(c)
+
+    failed = 0
+    results = []
+    for case in cases:
+        res = {
+            "name": case["name"],
+            "input": "\n".join(show(a) for a in case["args"]),
+            "expected": show(case["expected"]) if "expected" in case else "",
+            "actual": "",
+            "error": "",
+            "status": "",
+            "passed": True,
+        }
+        if "parse_error" in case:
+            res.update(status="Harness Error", error=case["parse_error"], passed=False)
+            failed += 1
+            results.append(res)
+            continue
+        buf = io.StringIO()
+        start = time.perf_counter()
+        try:
+            try:
+                if case_timeout:
+                    signal.setitimer(signal.ITIMER_REAL, case_timeout)
+                with contextlib.redirect_stdout(buf):
+                    got = call(case["args"])
+            finally:
+                if case_timeout:
+                    signal.setitimer(signal.ITIMER_REAL, 0)
+            if hasattr(got, "val") and "expected" in case and not isinstance(case["expected"], list):
+                # Node-returning problems whose answer is the node's value.
+                got = got.val
+            got = serialize(got)
+            res["actual"] = show(got)
+            if "expected" in case:
+                if same(case["expected"], got):
+                    res["status"] = "Accepted"
+                else:
+                    res["status"] = "Wrong Answer"
+                    res["passed"] = False
+        except TimeLimitExceeded:
+            res["status"] = "Time Limit Exceeded"
+            res["error"] = "Time Limit Exceeded: case ran longer than %gs" % case_timeout
+            res["passed"] = False
+        except MemoryError:
+            res["status"] = "Memory Limit Exceeded"
+            res["error"] = "Memory Limit Exceeded: MemoryError"
+            res["passed"] = False
+        except Exception:
+            res["status"] = "Runtime Error"
+            res["error"] = traceback.format_exc()
+            res["passed"] = False
+        res["elapsed_ms"] = (time.perf_counter() - start) * 1000
+        res["stdout"] = buf.getvalue()
+        if not res["passed"]:
+            failed += 1
+        results.append(res)
+
+    print(json.dumps({"passed": failed == 0, "failed": failed, "cases": results}))
 
 
 if __name__ == "__main__":
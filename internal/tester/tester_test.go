@@ -0,0 +1,250 @@
+package tester
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// runner holds one language's solutions for the shared scenarios below.
+type runner struct {
+	name     string
+	tool     string
+	file     string
+	run      func(string, Suite) (Result, error)
+	accepted string
+	wrong    string
+	broken   string
+	// brokenStatus is what a solution that does not compile reports.
+	brokenStatus string
+	// slow spins forever when target is 0.
+	slow   string
+	design string
+}
+
+var runners = []runner{
+	{
+		name: "python",
+		tool: "python3",
+		file: "solution.py",
+		run:  RunPython,
+		accepted: `class Solution:
+    def twoSum(self, nums: List[int], target: int) -> List[int]:
+        seen = {}
+        for i, n in enumerate(nums):
+            if target - n in seen:
+                return [seen[target - n], i]
+            seen[n] = i
+        return []
+`,
+		wrong: `class Solution:
+    def twoSum(self, nums: List[int], target: int) -> List[int]:
+        return [1, 0]
+`,
+		broken: `class Solution:
+    def twoSum(self, nums: List[int], target: int) -> List[int]
+        return []
+`,
+		brokenStatus: StatusRuntimeError,
+		slow: `class Solution:
+    def twoSum(self, nums: List[int], target: int) -> List[int]:
+        while target == 0:
+            pass
+        seen = {}
+        for i, n in enumerate(nums):
+            if target - n in seen:
+                return [seen[target - n], i]
+            seen[n] = i
+        return []
+`,
+		design: `class Counter:
+    def __init__(self, start: int):
+        self.n = start
+
+    def add(self, k: int) -> int:
+        self.n += k
+        return self.n
+`,
+	},
+	{
+		name: "go",
+		tool: "go",
+		file: "solution.go",
+		run:  RunGo,
+		accepted: `func twoSum(nums []int, target int) []int {
+	seen := map[int]int{}
+	for i, n := range nums {
+		if j, ok := seen[target-n]; ok {
+			return []int{j, i}
+		}
+		seen[n] = i
+	}
+	return nil
+}
+`,
+		wrong: `func twoSum(nums []int, target int) []int {
+	return []int{1, 0}
+}
+`,
+		broken: `func twoSum(nums []int, target int) []int {
+	return undefinedName
+}
+`,
+		brokenStatus: StatusCompileError,
+		slow: `func twoSum(nums []int, target int) []int {
+	for target == 0 {
+	}
+	seen := map[int]int{}
+	for i, n := range nums {
+		if j, ok := seen[target-n]; ok {
+			return []int{j, i}
+		}
+		seen[n] = i
+	}
+	return nil
+}
+`,
+		design: `type Counter struct{ n int }
+
+func Constructor(start int) Counter { return Counter{n: start} }
+
+func (c *Counter) Add(k int) int {
+	c.n += k
+	return c.n
+}
+`,
+	},
+}
+
+var twoSum = Suite{
+	ExampleTests:   "[2,7,11,15]\n9\n[3,2,4]\n6",
+	ExampleOutputs: []string{"[0,1]", "[1,2]"},
+	Limits:         Limits{CaseTimeout: 500 * time.Millisecond, RunTimeout: 30 * time.Second},
+}
+
+func writeSolution(t *testing.T, r runner, src string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), r.file)
+	if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func forEachRunner(t *testing.T, fn func(t *testing.T, r runner)) {
+	for _, r := range runners {
+		t.Run(r.name, func(t *testing.T) {
+			if _, err := exec.LookPath(r.tool); err != nil {
+				t.Skipf("%s not on PATH", r.tool)
+			}
+			fn(t, r)
+		})
+	}
+}
+
+func TestRunAccepted(t *testing.T) {
+	forEachRunner(t, func(t *testing.T, r runner) {
+		res, err := r.run(writeSolution(t, r, r.accepted), twoSum)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !res.Passed || res.Status != StatusAccepted || res.FailedCount != 0 {
+			t.Fatalf("got passed=%v status=%q failed=%d, want Accepted\n%s", res.Passed, res.Status, res.FailedCount, res.Output)
+		}
+		if len(res.Cases) != 2 {
+			t.Errorf("got %d cases, want 2", len(res.Cases))
+		}
+	})
+}
+
+func TestRunWrongAnswer(t *testing.T) {
+	forEachRunner(t, func(t *testing.T, r runner) {
+		res, err := r.run(writeSolution(t, r, r.wrong), twoSum)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Passed || res.Status != StatusWrongAnswer || res.FailedCount != 2 {
+			t.Fatalf("got passed=%v status=%q failed=%d, want 2 Wrong Answers\n%s", res.Passed, res.Status, res.FailedCount, res.Output)
+		}
+		if c := res.Cases[0]; c.Expected != "[0,1]" || c.Actual != "[1,0]" {
+			t.Errorf("case 1 expected=%q actual=%q", c.Expected, c.Actual)
+		}
+	})
+}
+
+func TestRunCompileError(t *testing.T) {
+	forEachRunner(t, func(t *testing.T, r runner) {
+		path := writeSolution(t, r, r.broken)
+		res, err := r.run(path, twoSum)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Passed || res.Status != r.brokenStatus {
+			t.Fatalf("got passed=%v status=%q, want %s\n%s", res.Passed, res.Status, r.brokenStatus, res.Output)
+		}
+		want := "SyntaxError"
+		if r.brokenStatus == StatusCompileError {
+			want = path
+		}
+		if !strings.Contains(res.Output, want) {
+			t.Errorf("output does not mention %s:\n%s", want, res.Output)
+		}
+	})
+}
+
+func TestRunTimeLimit(t *testing.T) {
+	forEachRunner(t, func(t *testing.T, r runner) {
+		suite := twoSum
+		suite.UserCases = []UserTestCase{
+			{Input: []any{[]any{1, 2}, 0}, Expected: []any{0, 1}},
+			{Input: []any{[]any{1, 2}, 3}, Expected: []any{0, 1}},
+		}
+		res, err := r.run(writeSolution(t, r, r.slow), suite)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Passed || res.Status != StatusTimeLimit {
+			t.Fatalf("got passed=%v status=%q, want Time Limit Exceeded\n%s", res.Passed, res.Status, res.Output)
+		}
+		if len(res.Cases) != 4 {
+			t.Fatalf("got %d cases, want 4", len(res.Cases))
+		}
+		if got := res.Cases[2].Status; got != StatusTimeLimit {
+			t.Errorf("slow case status = %q, want %s", got, StatusTimeLimit)
+		}
+		// Python interrupts the slow case and goes on; Go cannot stop the
+		// goroutine and skips the rest.
+		wantLast, wantNotRun := StatusAccepted, 0
+		if r.name == "go" {
+			wantLast, wantNotRun = StatusNotRun, 1
+		}
+		if got := res.Cases[3].Status; got != wantLast {
+			t.Errorf("case after the slow one = %q, want %s", got, wantLast)
+		}
+		if res.NotRun != wantNotRun || res.FailedCount != 1 {
+			t.Errorf("got failed=%d notRun=%d, want failed=1 notRun=%d", res.FailedCount, res.NotRun, wantNotRun)
+		}
+	})
+}
+
+func TestRunDesignReplay(t *testing.T) {
+	forEachRunner(t, func(t *testing.T, r runner) {
+		suite := Suite{
+			ExampleTests:   `["Counter","add","add"]` + "\n" + `[[1],[2],[3]]`,
+			ExampleOutputs: []string{"[null,3,6]"},
+		}
+		res, err := r.run(writeSolution(t, r, r.design), suite)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !res.Passed {
+			t.Fatalf("got status=%q, want Accepted\n%s\n%+v", res.Status, res.Output, res.Cases)
+		}
+		if got := res.Cases[0].Actual; got != "[null,3,6]" {
+			t.Errorf("replay output = %q, want [null,3,6]", got)
+		}
+	})
+}
@@ -0,0 +1,370 @@
+package workspace
+
+import (
+	"html"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	mdTagRe        = regexp.MustCompile(`(?s)^<(/?)([a-zA-Z][a-zA-Z0-9]*)([^>]*?)(/?)>`)
+	mdAttrRe       = regexp.MustCompile(`([a-zA-Z-]+)\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s>]+))`)
+	mdAnyTagRe     = regexp.MustCompile(`<[^>]*>`)
+	mdSpaceRe      = regexp.MustCompile(`[ \t\r\n\f]+`)
+	mdBlankLinesRe = regexp.MustCompile(`\n{3,}`)
+	// mdEscaper keeps plain-text 2*n*m or snake_case from turning into
+	// emphasis.
+	mdEscaper = strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`)
+)
+
+// HTMLToMarkdown converts a LeetCode statement (or any fragment using the
+// same small tag set) into Markdown: paragraphs, <pre> code blocks, inline
+// code, bold/italics, nested lists, links, images, tables, and <sup>/<sub>
+// written as 10^4 and nums_i. Unknown tags are dropped and their text kept.
+func HTMLToMarkdown(fragment string) string {
+	c := mdConverter{}
+	rest := fragment
+	for rest != "" {
+		i := strings.IndexByte(rest, '<')
+		if i < 0 {
+			c.text(rest)
+			break
+		}
+		if i > 0 {
+			c.text(rest[:i])
+			rest = rest[i:]
+			continue
+		}
+		m := mdTagRe.FindStringSubmatch(rest)
+		if m == nil {
+			if strings.HasPrefix(rest, "<!--") {
+				if end := strings.Index(rest, "-->"); end >= 0 {
+					rest = rest[end+3:]
+					continue
+				}
+			}
+			c.text("<")
+			rest = rest[1:]
+			continue
+		}
+		rest = rest[len(m[0]):]
+		name := strings.ToLower(m[2])
+		if m[1] == "/" {
+			c.close(name)
+			continue
+		}
+		if name == "sup" || name == "sub" {
+			inner, after := splitAtClose(rest, name)
+			c.script(name, inner)
+			rest = after
+			continue
+		}
+		c.open(name, parseAttrs(m[3]))
+	}
+	return c.finish()
+}
+
+type mdList struct {
+	ordered bool
+	n       int
+}
+
+// mdTable collects cells so the table can be written once its column count
+// is known; the first row is the header.
+type mdTable struct {
+	rows [][]string
+	// outer is the output before the table while cells are written to the
+	// converter's builder.
+	outer  string
+	inCell bool
+}
+
+type mdConverter struct {
+	b    strings.Builder
+	pre  int
+	code int
+	// preStart drops the newline that conventionally follows <pre>.
+	preStart bool
+	lists    []mdList
+	links    []string
+	table    *mdTable
+}
+
+func (c *mdConverter) tail() string {
+	s := c.b.String()
+	if len(s) > 2 {
+		return s[len(s)-2:]
+	}
+	return s
+}
+
+// block ends the current block with a blank line.
+func (c *mdConverter) block() {
+	switch t := c.tail(); {
+	case t == "" || t == "\n\n":
+	case strings.HasSuffix(t, "\n"):
+		c.b.WriteString("\n")
+	default:
+		c.b.WriteString("\n\n")
+	}
+}
+
+func (c *mdConverter) newline() {
+	if t := c.tail(); t != "" && !strings.HasSuffix(t, "\n") {
+		c.b.WriteString("\n")
+	}
+}
+
+func (c *mdConverter) text(raw string) {
+	s := html.UnescapeString(raw)
+	if c.pre > 0 {
+		if c.preStart {
+			s = strings.TrimPrefix(s, "\n")
+			c.preStart = false
+		}
+		c.b.WriteString(s)
+		return
+	}
+	s = strings.ReplaceAll(s, "\u00a0", " ")
+	s = mdSpaceRe.ReplaceAllString(s, " ")
+	if c.code == 0 {
+		s = mdEscaper.Replace(s)
+	}
+	if t := c.tail(); t == "" || strings.HasSuffix(t, "\n") || strings.HasSuffix(t, " ") {
+		s = strings.TrimLeft(s, " ")
+	}
+	c.b.WriteString(s)
+}
+
+func (c *mdConverter) open(name string, attrs map[string]string) {
+	if c.pre > 0 {
+		if name == "pre" {
+			c.pre++
+		}
+		return
+	}
+	switch name {
+	case "p", "div", "blockquote":
+		c.block()
+	case "table":
+		if c.table == nil {
+			c.block()
+			c.table = &mdTable{outer: c.b.String()}
+			c.b.Reset()
+		}
+	case "br":
+		c.b.WriteString("\n")
+	case "pre":
+		c.block()
+		c.b.WriteString("```\n")
+		c.pre++
+		c.preStart = true
+	case "code", "tt":
+		c.code++
+		c.b.WriteString("`")
+	case "strong", "b":
+		c.b.WriteString("**")
+	case "em", "i":
+		c.b.WriteString("*")
+	case "ul", "ol":
+		if len(c.lists) == 0 {
+			c.block()
+		}
+		c.lists = append(c.lists, mdList{ordered: name == "ol"})
+	case "li":
+		c.newline()
+		depth := max(len(c.lists), 1)
+		c.b.WriteString(strings.Repeat("  ", depth-1))
+		if len(c.lists) > 0 && c.lists[len(c.lists)-1].ordered {
+			c.lists[len(c.lists)-1].n++
+			c.b.WriteString(strconv.Itoa(c.lists[len(c.lists)-1].n) + ". ")
+		} else {
+			c.b.WriteString("- ")
+		}
+	case "a":
+		c.links = append(c.links, attrs["href"])
+		c.b.WriteString("[")
+	case "img":
+		c.b.WriteString("![" + attrs["alt"] + "](" + attrs["src"] + ")")
+	case "tr":
+		if c.table != nil {
+			c.endCell()
+			c.table.rows = append(c.table.rows, nil)
+		}
+	case "td", "th":
+		if c.table != nil {
+			c.endCell()
+			if len(c.table.rows) == 0 {
+				c.table.rows = append(c.table.rows, nil)
+			}
+			c.table.inCell = true
+		}
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		c.block()
+		level, _ := strconv.Atoi(name[1:])
+		c.b.WriteString(strings.Repeat("#", min(level+2, 6)) + " ")
+	}
+}
+
+func (c *mdConverter) close(name string) {
+	if c.pre > 0 {
+		if name == "pre" {
+			c.pre--
+			if c.pre == 0 {
+				c.newline()
+				c.b.WriteString("```")
+				c.block()
+			}
+		}
+		return
+	}
+	switch name {
+	case "p", "div", "blockquote", "h1", "h2", "h3", "h4", "h5", "h6":
+		c.block()
+	case "table":
+		c.writeTable()
+	case "td", "th", "tr":
+		c.endCell()
+	case "code", "tt":
+		c.code = max(c.code-1, 0)
+		c.b.WriteString("`")
+	case "strong", "b":
+		c.closeEmphasis("**")
+	case "em", "i":
+		c.closeEmphasis("*")
+	case "ul", "ol":
+		if len(c.lists) > 0 {
+			c.lists = c.lists[:len(c.lists)-1]
+		}
+		if len(c.lists) == 0 {
+			c.block()
+		}
+	case "a":
+		href := ""
+		if len(c.links) > 0 {
+			href = c.links[len(c.links)-1]
+			c.links = c.links[:len(c.links)-1]
+		}
+		c.b.WriteString("](" + href + ")")
+	}
+}
+
+// endCell moves the text written since the cell opened into the current row.
+func (c *mdConverter) endCell() {
+	t := c.table
+	if t == nil {
+		return
+	}
+	if !t.inCell {
+		// Drop whitespace between rows and cells.
+		c.b.Reset()
+		return
+	}
+	cell := strings.TrimSpace(mdSpaceRe.ReplaceAllString(c.b.String(), " "))
+	c.b.Reset()
+	t.rows[len(t.rows)-1] = append(t.rows[len(t.rows)-1], strings.ReplaceAll(cell, "|", `\|`))
+	t.inCell = false
+}
+
+// writeTable restores the output before the table and appends the table
+// with a header separator, padding short rows.
+func (c *mdConverter) writeTable() {
+	t := c.table
+	if t == nil {
+		return
+	}
+	c.endCell()
+	c.table = nil
+	c.b.WriteString(t.outer)
+	cols := 0
+	for _, r := range t.rows {
+		cols = max(cols, len(r))
+	}
+	if cols == 0 {
+		return
+	}
+	row := func(cells []string) {
+		for len(cells) < cols {
+			cells = append(cells, "")
+		}
+		c.b.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+	first := true
+	for _, r := range t.rows {
+		if len(r) == 0 {
+			continue
+		}
+		row(r)
+		if first {
+			row(slices.Repeat([]string{"---"}, cols))
+			first = false
+		}
+	}
+	c.block()
+}
+
+// closeEmphasis writes the closing marker before any trailing space, since
+// "**bold **" does not render as bold.
+func (c *mdConverter) closeEmphasis(mark string) {
+	s := c.b.String()
+	trimmed := strings.TrimRight(s, " ")
+	if trimmed == s {
+		c.b.WriteString(mark)
+		return
+	}
+	c.b.Reset()
+	c.b.WriteString(trimmed + mark + " ")
+}
+
+// script writes <sup>/<sub> content inline: 10^4, 10^(-9), nums_i.
+func (c *mdConverter) script(name, inner string) {
+	text := strings.TrimSpace(html.UnescapeString(mdAnyTagRe.ReplaceAllString(inner, "")))
+	if text == "" {
+		return
+	}
+	mark := "^"
+	if name == "sub" {
+		mark = "_"
+	}
+	if c.pre == 0 && !isAlnum(text) {
+		text = "(" + text + ")"
+	}
+	c.b.WriteString(mark + text)
+}
+
+func (c *mdConverter) finish() string {
+	out := strings.ReplaceAll(c.b.String(), "\r\n", "\n")
+	lines := strings.Split(out, "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimRight(l, " \t")
+	}
+	out = mdBlankLinesRe.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+	return strings.TrimSpace(out) + "\n"
+}
+
+func splitAtClose(rest, name string) (string, string) {
+	end := strings.Index(strings.ToLower(rest), "</"+name+">")
+	if end < 0 {
+		return rest, ""
+	}
+	return rest[:end], rest[end+len(name)+3:]
+}
+
+func parseAttrs(raw string) map[string]string {
+	out := map[string]string{}
+	for _, m := range mdAttrRe.FindAllStringSubmatch(raw, -1) {
+		out[strings.ToLower(m[1])] = html.UnescapeString(m[2] + m[3] + m[4])
+	}
+	return out
+}
+
+func isAlnum(s string) bool {
+	for _, r := range s {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+			return false
+		}
+	}
+	return true
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"leetcli/internal/lang"
 	"leetcli/internal/store"
 )
 
@@ -39,24 +40,23 @@ func ProblemDir(problemsDir, slug string) string {
 	return filepath.Join(problemsDir, slug)
 }
 
-func EnsureProblemFiles(problemsDir string, p store.ProblemRow) error {
+func SolutionPath(problemsDir, slug string, l lang.Language) string {
+	return filepath.Join(ProblemDir(problemsDir, slug), l.FileName())
+}
+
+func EnsureProblemFiles(problemsDir string, p store.ProblemRow, l lang.Language) error {
 	dir := ProblemDir(problemsDir, p.Slug)
 	if err := os.MkdirAll(dir, 0o755); err != nil {
 		return fmt.Errorf("create problem dir: %w", err)
 	}
 
-	readmePath := filepath.Join(dir, "README.md")
-	if err := os.WriteFile(readmePath, []byte(renderReadme(p)), 0o644); err != nil {
-		return fmt.Errorf("write README: %w", err)
+	if err := WriteReadme(problemsDir, p); err != nil {
+		return err
 	}
 
-	solutionPath := filepath.Join(dir, "solution.py")
+	solutionPath := SolutionPath(problemsDir, p.Slug, l)
 	if _, err := os.Stat(solutionPath); os.IsNotExist(err) {
-		stub := strings.TrimSpace(p.CodeStub)
-		if stub == "" {
-			stub = "class Solution:\n    pass\n"
-		}
-		if err := os.WriteFile(solutionPath, []byte(stub+"\n"), 0o644); err != nil {
+		if err := os.WriteFile(solutionPath, []byte(l.Stub(p.CodeStubs)), 0o644); err != nil {
 			return fmt.Errorf("write solution: %w", err)
 		}
 	}
@@ -72,6 +72,50 @@ func EnsureProblemFiles(problemsDir string, p store.ProblemRow) error {
 	return nil
 }
 
+// WriteReadme renders p's README into its problem directory.
+func WriteReadme(problemsDir string, p store.ProblemRow) error {
+	readmePath := filepath.Join(ProblemDir(problemsDir, p.Slug), "README.md")
+	if err := os.WriteFile(readmePath, []byte(renderReadme(p)), 0o644); err != nil {
+		return fmt.Errorf("write README: %w", err)
+	}
+	return nil
+}
+
+// ReviewSolutionPath is the solution file of slug's review copy, kept apart
+// from the original solution.
+func ReviewSolutionPath(problemsDir, slug string, l lang.Language) string {
+	return filepath.Join(ProblemDir(problemsDir, slug), "review", l.FileName())
+}
+
+// PrepareReview writes a fresh stub into the review copy of p, replacing any
+// earlier review attempt, and returns its path.
+func PrepareReview(problemsDir string, p store.ProblemRow, l lang.Language) (string, error) {
+	path := ReviewSolutionPath(problemsDir, p.Slug, l)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", fmt.Errorf("create review dir: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(l.Stub(p.CodeStubs)), 0o644); err != nil {
+		return "", fmt.Errorf("write review solution: %w", err)
+	}
+	return path, nil
+}
+
+// SeedSolution writes code as the problem's solution file unless one already
+// exists. It reports whether the file was written.
+func SeedSolution(problemsDir, slug string, l lang.Language, code string) (bool, error) {
+	path := SolutionPath(problemsDir, slug, l)
+	if _, err := os.Stat(path); err == nil {
+		return false, nil
+	}
+	if err := os.MkdirAll(ProblemDir(problemsDir, slug), 0o755); err != nil {
+		return false, fmt.Errorf("create problem dir: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(l.Header+strings.TrimSpace(code)+"\n"), 0o644); err != nil {
+		return false, fmt.Errorf("write solution: %w", err)
+	}
+	return true, nil
+}
+
 func WriteMetaJSON(problemsDir string, p store.ProblemRow) error {
 	m := ProblemMeta{
 		Slug:         p.Slug,
@@ -85,6 +129,9 @@ func WriteMetaJSON(problemsDir string, p store.ProblemRow) error {
 		Memory:       p.Memory,
 		UpdatedAt:    p.UpdatedAt,
 	}
+	if p.Blind {
+		m.Difficulty, m.Topics = "", nil
+	}
 	b, err := json.MarshalIndent(m, "", "  ")
 	if err != nil {
 		return err
@@ -118,15 +165,60 @@ func OpenInEditor(path string) error {
 }
 
 func renderReadme(p store.ProblemRow) string {
+	if p.Blind {
+		return renderBlindReadme(p)
+	}
 	topicLine := ""
 	if len(p.Topics) > 0 {
 		topicLine = strings.Join(p.Topics, ", ")
 	}
-	return fmt.Sprintf(`# %s
+	var b strings.Builder
+	fmt.Fprintf(&b, `# %s
 
 - Slug: %s
 - Difficulty: %s
 - Topics: %s
+`, p.Title, p.Slug, p.Difficulty, topicLine)
+	if p.AcRate > 0 {
+		fmt.Fprintf(&b, "- Acceptance: %.1f%%\n", p.AcRate)
+	}
+	if p.Likes > 0 || p.Dislikes > 0 {
+		fmt.Fprintf(&b, "- Likes: %d / Dislikes: %d\n", p.Likes, p.Dislikes)
+	}
+	fmt.Fprintf(&b, `
+## Statement
+
+%s
+
+## Example Testcases (best effort)
+
+`+"```text\n%s\n```\n", strings.TrimSpace(HTMLToMarkdown(p.StatementHTML)), p.ExampleTests)
+
+	if len(p.Hints) > 0 {
+		b.WriteString("\n## Hints\n\n")
+		for i, h := range p.Hints {
+			if i > 0 {
+				b.WriteString("\n")
+			}
+			fmt.Fprintf(&b, "<details>\n<summary>Hint %d</summary>\n\n%s\n\n</details>\n", i+1, strings.TrimSpace(HTMLToMarkdown(h)))
+		}
+	}
+	if len(p.Similar) > 0 {
+		b.WriteString("\n## Similar Questions\n\n")
+		for _, sp := range p.Similar {
+			fmt.Fprintf(&b, "- %s (`%s`, %s)\n", sp.Title, sp.Slug, sp.Difficulty)
+		}
+	}
+	return b.String()
+}
+
+// renderBlindReadme is the README of a problem in a running mock interview:
+// the statement and examples only, without difficulty, topics, stats, hints
+// or similar questions.
+func renderBlindReadme(p store.ProblemRow) string {
+	return fmt.Sprintf(`# %s
+
+- Slug: %s
 
 ## Statement
 
@@ -134,5 +226,5 @@ func renderReadme(p store.ProblemRow) string {
 
 ## Example Testcases (best effort)
 
-`+"```text\n%s\n```\n", p.Title, p.Slug, p.Difficulty, topicLine, p.StatementHTML, p.ExampleTests)
+`+"```text\n%s\n```\n", p.Title, p.Slug, strings.TrimSpace(HTMLToMarkdown(p.StatementHTML)), p.ExampleTests)
 }